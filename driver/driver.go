@@ -8,6 +8,9 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/machbase/neo-client/machrpc"
@@ -20,9 +23,21 @@ func init() {
 const Name = "machbase"
 
 var configReigstry = map[string]*DataSource{}
+var configRegistryLock sync.Mutex
 
+// RegisterDataSource registers the data source of the name.
+// If the name is registered again with other configuration, the new connections use
+// the new configuration, the client of the old one is closed when its connections are closed.
 func RegisterDataSource(name string, conf *DataSource) {
+	configRegistryLock.Lock()
 	configReigstry[name] = conf
+	configRegistryLock.Unlock()
+
+	clientRegistryLock.Lock()
+	defer clientRegistryLock.Unlock()
+	if ent, ok := clientRegistry[name]; ok && (conf == nil || ent.ds != *conf) {
+		delete(clientRegistry, name)
+	}
 }
 
 type DataSource struct {
@@ -30,6 +45,8 @@ type DataSource struct {
 	ServerCert string
 	ClientKey  string
 	ClientCert string
+	// ServerName overrides the name that verifies the server certificate
+	ServerName string
 	User       string
 	Password   string
 }
@@ -41,6 +58,7 @@ func (conf *DataSource) newClient() (*machrpc.Client, error) {
 			ClientCert: conf.ClientCert,
 			ClientKey:  conf.ClientKey,
 			ServerCert: conf.ServerCert,
+			ServerName: conf.ServerName,
 		},
 	})
 }
@@ -70,6 +88,7 @@ func parseDataSourceName(name string) (*DataSource, error) {
 	return &DataSource{
 		ServerAddr: addr,
 		ServerCert: serverCert,
+		ServerName: vals.Get("server-name"),
 		User:       user,
 		Password:   password,
 	}, nil
@@ -77,7 +96,10 @@ func parseDataSourceName(name string) (*DataSource, error) {
 
 func makeClientConfig(dsn string) (*DataSource, error) {
 	var conf *DataSource
-	if c, ok := configReigstry[dsn]; ok {
+	configRegistryLock.Lock()
+	c, ok := configReigstry[dsn]
+	configRegistryLock.Unlock()
+	if ok {
 		conf = c
 	} else {
 		parsedConf, err := parseDataSourceName(dsn)
@@ -89,13 +111,59 @@ func makeClientConfig(dsn string) (*DataSource, error) {
 	return conf, nil
 }
 
+// sharedClient is a machrpc.Client that is shared by the connections of a data source.
+type sharedClient struct {
+	name   string
+	ds     DataSource // the configuration that the client is made of
+	client *machrpc.Client
+	refs   int // number of the connectors and the connections of Open that use the client
+}
+
+var clientRegistry = map[string]*sharedClient{}
+var clientRegistryLock sync.Mutex
+
+// acquireClient returns the shared client of the data source name,
+// so that all connections of the same data source share a gRPC connection.
+// The client is made again if the configuration of the name is changed.
+// The caller should call releaseClient when it does not use the client any more.
+func acquireClient(name string, ds *DataSource) (*sharedClient, error) {
+	clientRegistryLock.Lock()
+	defer clientRegistryLock.Unlock()
+	if ent, ok := clientRegistry[name]; ok && ent.ds == *ds {
+		ent.refs++
+		return ent, nil
+	}
+	client, err := ds.newClient()
+	if err != nil {
+		return nil, err
+	}
+	ent := &sharedClient{name: name, ds: *ds, client: client, refs: 1}
+	// the previous client of the name, if any, is closed by its last user
+	clientRegistry[name] = ent
+	return ent, nil
+}
+
+// releaseClient closes the client when it is released by all users.
+func releaseClient(ent *sharedClient) {
+	clientRegistryLock.Lock()
+	ent.refs--
+	closing := ent.refs == 0
+	if closing && clientRegistry[ent.name] == ent {
+		delete(clientRegistry, ent.name)
+	}
+	clientRegistryLock.Unlock()
+	if closing {
+		ent.client.Close()
+	}
+}
+
 // implments sql.Driver
 func (d *NeoDriver) Open(name string) (driver.Conn, error) {
 	ds, err := makeClientConfig(name)
 	if err != nil {
 		return nil, err
 	}
-	client, err := ds.newClient()
+	shared, err := acquireClient(name, ds)
 	if err != nil {
 		return nil, err
 	}
@@ -103,14 +171,16 @@ func (d *NeoDriver) Open(name string) (driver.Conn, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	conn, err := client.Connect(ctx, machrpc.WithPassword(ds.User, ds.Password))
+	conn, err := shared.client.Connect(ctx, machrpc.WithPassword(ds.User, ds.Password))
 	if err != nil {
-		return nil, err
+		releaseClient(shared)
+		return nil, connError(err)
 	}
 
 	ret := &NeoConn{
-		name: name,
-		conn: conn,
+		name:   name,
+		conn:   conn,
+		shared: shared,
 	}
 	return ret, nil
 }
@@ -121,22 +191,25 @@ func (d *NeoDriver) OpenConnector(name string) (driver.Connector, error) {
 	if err != nil {
 		return nil, err
 	}
-	client, err := ds.newClient()
+	shared, err := acquireClient(name, ds)
 	if err != nil {
 		return nil, err
 	}
 
-	ok, err := client.UserAuth(ds.User, ds.Password)
+	ok, err := shared.client.UserAuth(ds.User, ds.Password)
 	if err != nil {
+		releaseClient(shared)
 		return nil, err
 	}
 	if !ok {
+		releaseClient(shared)
 		return nil, fmt.Errorf("invalid username or password")
 	}
 	conn := &NeoConnector{
 		name:     name,
 		driver:   d,
-		client:   client,
+		client:   shared.client,
+		shared:   shared,
 		user:     ds.User,
 		password: ds.Password,
 	}
@@ -148,14 +221,26 @@ type NeoConnector struct {
 	name     string
 	driver   *NeoDriver
 	client   *machrpc.Client
+	shared   *sharedClient
 	user     string
 	password string
+	closed   sync.Once
+}
+
+var _ io.Closer = &NeoConnector{}
+
+// Close releases the shared client of the connector, it is called by sql.DB.Close.
+func (cn *NeoConnector) Close() error {
+	cn.closed.Do(func() {
+		releaseClient(cn.shared)
+	})
+	return nil
 }
 
 func (cn *NeoConnector) Connect(ctx context.Context) (driver.Conn, error) {
 	conn, err := cn.client.Connect(ctx, machrpc.WithPassword(cn.user, cn.password))
 	if err != nil {
-		return nil, err
+		return nil, connError(err)
 	}
 	ret := &NeoConn{
 		name: cn.name,
@@ -176,14 +261,19 @@ type NeoConn struct {
 	driver.ExecerContext
 	driver.ConnPrepareContext
 
-	name string
-	conn *machrpc.Conn
+	name   string
+	conn   *machrpc.Conn
+	shared *sharedClient // the client that is released with the connection, nil if the connector owns it
 }
 
 func (c *NeoConn) Close() error {
 	if c.conn != nil {
 		c.conn.Close()
 		c.conn = nil
+		if c.shared != nil {
+			releaseClient(c.shared)
+			c.shared = nil
+		}
 	}
 	return nil
 }
@@ -203,7 +293,7 @@ func (c *NeoConn) QueryContext(ctx context.Context, query string, args []driver.
 	}
 	rows, err := c.conn.Query(ctx, query, vals...)
 	if err != nil {
-		return nil, err
+		return nil, driverError(err)
 	}
 	return &NeoRows{rows: rows}, nil
 }
@@ -215,7 +305,7 @@ func (c *NeoConn) ExecContext(ctx context.Context, query string, args []driver.N
 	}
 	row := c.conn.QueryRow(ctx, query, vals...)
 	if row.Err() != nil {
-		return nil, row.Err()
+		return nil, driverError(row.Err())
 	}
 	return &NeoResult{row: row}, nil
 }
@@ -234,8 +324,32 @@ func (c *NeoConn) PrepareContext(ctx context.Context, query string) (driver.Stmt
 }
 
 func (c *NeoConn) Ping(ctx context.Context) error {
-	// return driver.ErrBadConn
-	return nil
+	if c.conn == nil {
+		return driver.ErrBadConn
+	}
+	_, err := c.conn.PingContext(ctx)
+	return connError(err)
+}
+
+// driverError marks the error with driver.ErrBadConn only if the statement provably did not run,
+// so that database/sql retries the operation with a new connection.
+// The server rejects the lost session before it runs the statement.
+// The transport errors (e.g. ErrUnavailable) are returned as they are,
+// since the statement may have run before the connection broke.
+func driverError(err error) error {
+	if errors.Is(err, machrpc.ErrSessionLost) {
+		return fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+	}
+	return err
+}
+
+// connError marks the error of Connect and Ping with driver.ErrBadConn
+// if the server is not available, they run no statement.
+func connError(err error) error {
+	if errors.Is(err, machrpc.ErrUnavailable) {
+		return fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+	}
+	return driverError(err)
 }
 
 type NeoTx struct {
@@ -274,7 +388,7 @@ func (stmt *NeoStmt) Exec(args []driver.Value) (driver.Result, error) {
 	}
 	row := stmt.conn.QueryRow(context.TODO(), stmt.sqlText, vals...)
 	if row.Err() != nil {
-		return nil, row.Err()
+		return nil, driverError(row.Err())
 	}
 	return &NeoResult{row: row}, nil
 }
@@ -286,7 +400,7 @@ func (stmt *NeoStmt) ExecContext(ctx context.Context, args []driver.NamedValue)
 	}
 	row := stmt.conn.QueryRow(ctx, stmt.sqlText, vals...)
 	if row.Err() != nil {
-		return nil, row.Err()
+		return nil, driverError(row.Err())
 	}
 	return &NeoResult{row: row}, nil
 }
@@ -304,7 +418,7 @@ func (stmt *NeoStmt) Query(args []driver.Value) (driver.Rows, error) {
 	}
 	rows, err := stmt.conn.Query(ctx, stmt.sqlText, vals...)
 	if err != nil {
-		return nil, err
+		return nil, driverError(err)
 	}
 	return &NeoRows{rows: rows}, nil
 }
@@ -316,7 +430,7 @@ func (stmt *NeoStmt) QueryContext(ctx context.Context, args []driver.NamedValue)
 	}
 	rows, err := stmt.conn.Query(ctx, stmt.sqlText, vals...)
 	if err != nil {
-		return nil, err
+		return nil, driverError(err)
 	}
 	return &NeoRows{rows: rows}, nil
 }
@@ -341,6 +455,11 @@ type NeoRows struct {
 	colNames []string
 }
 
+var _ driver.RowsColumnTypeScanType = &NeoRows{}
+var _ driver.RowsColumnTypeDatabaseTypeName = &NeoRows{}
+var _ driver.RowsColumnTypeLength = &NeoRows{}
+var _ driver.RowsColumnTypeNullable = &NeoRows{}
+
 func (r *NeoRows) Columns() []string {
 	if r.colNames == nil {
 		r.colNames, _, _ = r.rows.Columns()
@@ -348,6 +467,46 @@ func (r *NeoRows) Columns() []string {
 	return r.colNames
 }
 
+func (r *NeoRows) columnType(index int) *machrpc.ColumnDescriptor {
+	types, err := r.rows.ColumnTypes()
+	if err != nil || index < 0 || index >= len(types) {
+		return nil
+	}
+	return types[index]
+}
+
+// implements driver.RowsColumnTypeScanType
+func (r *NeoRows) ColumnTypeScanType(index int) reflect.Type {
+	if ct := r.columnType(index); ct != nil {
+		return ct.ScanType
+	}
+	return reflect.TypeOf((*any)(nil)).Elem()
+}
+
+// implements driver.RowsColumnTypeDatabaseTypeName
+func (r *NeoRows) ColumnTypeDatabaseTypeName(index int) string {
+	if ct := r.columnType(index); ct != nil {
+		return strings.ToUpper(ct.TypeName)
+	}
+	return ""
+}
+
+// implements driver.RowsColumnTypeLength
+func (r *NeoRows) ColumnTypeLength(index int) (int64, bool) {
+	if ct := r.columnType(index); ct != nil && ct.Type.IsVariableLength() {
+		return int64(ct.Size), true
+	}
+	return 0, false
+}
+
+// implements driver.RowsColumnTypeNullable
+func (r *NeoRows) ColumnTypeNullable(index int) (bool, bool) {
+	if ct := r.columnType(index); ct != nil {
+		return ct.Nullable, true
+	}
+	return false, false
+}
+
 func (r *NeoRows) Close() error {
 	if r.rows == nil {
 		return nil
@@ -362,6 +521,9 @@ func (r *NeoRows) Close() error {
 
 func (r *NeoRows) Next(dest []driver.Value) error {
 	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
 		return io.EOF
 	}
 	vals := make([]any, len(dest))
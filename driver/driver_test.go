@@ -3,12 +3,14 @@ package driver_test
 import (
 	"context"
 	"database/sql"
+	sqldriver "database/sql/driver"
 	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/machbase/neo-client/driver"
+	"github.com/machbase/neo-client/machrpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -43,11 +45,45 @@ func TestConnectFail(t *testing.T) {
 	for _, tt := range tests {
 		db, err := sql.Open("machbase", tt)
 		require.NotNil(t, err)
-		require.Equal(t, "invalid username or password", err.Error())
+		require.Equal(t, "UserAuth: invalid username or password", err.Error())
+		require.ErrorIs(t, err, machrpc.ErrAuthFailed)
 		require.Nil(t, db)
 	}
 }
 
+func TestRegisterDataSource(t *testing.T) {
+	driver.RegisterDataSource("re-registered", &driver.DataSource{
+		ServerAddr: "tcp://127.0.0.1:1",
+		User:       "sys",
+		Password:   "manager",
+	})
+	_, err := sql.Open(driver.Name, "re-registered")
+	require.ErrorIs(t, err, machrpc.ErrUnavailable)
+
+	// the new configuration takes effect
+	driver.RegisterDataSource("re-registered", &driver.DataSource{
+		ServerAddr: "tcp://" + MockServerAddr,
+		User:       "sys",
+		Password:   "manager",
+	})
+	db, err := sql.Open(driver.Name, "re-registered")
+	require.Nil(t, err)
+	require.Nil(t, db.PingContext(context.TODO()))
+	require.Nil(t, db.Close())
+}
+
+func TestQueryError(t *testing.T) {
+	db := connect(t)
+	defer db.Close()
+
+	_, err := db.Query(`select * from no_such_table`)
+	require.ErrorIs(t, err, machrpc.ErrTableNotFound)
+	require.NotErrorIs(t, err, sqldriver.ErrBadConn)
+
+	_, err = db.Query(`selec * from example`)
+	require.ErrorIs(t, err, machrpc.ErrSyntax)
+}
+
 func TestQuery(t *testing.T) {
 	db := connect(t)
 	defer db.Close()
@@ -69,6 +105,17 @@ func TestQuery(t *testing.T) {
 	conn.Close()
 }
 
+func TestPing(t *testing.T) {
+	db := connect(t)
+	defer db.Close()
+
+	require.Nil(t, db.PingContext(context.TODO()))
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	require.ErrorIs(t, db.PingContext(ctx), context.Canceled)
+}
+
 func TestDriver(t *testing.T) {
 	driver.RegisterDataSource("local-unix", &driver.DataSource{
 		ServerAddr: "unix://../../neo-server/tmp/mach-grpc.sock",
@@ -178,3 +225,24 @@ func testDriverDataSource(t *testing.T, dataSourceName string) {
 	require.Equal(t, expectCount, count)
 	t.Logf("DB=%#v", db.Stats())
 }
+
+func TestColumnTypes(t *testing.T) {
+	db := connect(t)
+	defer db.Close()
+
+	rows, err := db.Query(`select * from example where name = ?`, "query1")
+	require.Nil(t, err)
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	require.Nil(t, err)
+	require.Equal(t, 3, len(types))
+	require.Equal(t, "VARCHAR", types[0].DatabaseTypeName())
+	length, ok := types[0].Length()
+	require.True(t, ok)
+	require.Equal(t, int64(40), length)
+	require.Equal(t, "Time", types[1].ScanType().Name())
+	nullable, ok := types[2].Nullable()
+	require.True(t, ok)
+	require.True(t, nullable)
+}
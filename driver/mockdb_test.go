@@ -4,35 +4,54 @@ import (
 	context "context"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/machbase/neo-client/machrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type MockServer struct {
 	machrpc.MachbaseServer
 	svr *grpc.Server
 
-	counter   int32
-	conns     map[string]*MockConn
-	rows      map[string]*MockRows
-	appenders map[string]*MockAppender
+	mu         sync.Mutex
+	counter    int32
+	appendFail bool // simulates the server that can not append
+	appendHang bool // simulates the server that does not receive the append stream
+	conns      map[string]*MockConn
+	rows       map[string]*MockRows
+	appenders  map[string]*MockAppender
+	killed     []string // ids of the killed sessions
 }
 
 type MockConn struct {
 }
 
 type MockRows struct {
-	nrow int
+	nrow  int
+	total int
+	nulls bool // the value column is int64 of NULL
+}
+
+// values returns the values of the current row.
+func (mr *MockRows) values() []any {
+	if mr.nulls {
+		return []any{"tag", time.Unix(0, int64(mr.nrow)), int64(math.MinInt64)}
+	}
+	return []any{"tag", time.Unix(0, int64(mr.nrow)), 3.14}
 }
 
 type MockAppender struct {
-	table string
-	nrow  int
+	table   string
+	nrow    int
+	records [][]any
 }
 
 var _ machrpc.MachbaseServer = &MockServer{}
@@ -72,6 +91,27 @@ func (ms *MockServer) Stop() {
 	// }
 }
 
+// SetAppendFail makes Appender and Append fail as if the server is not available.
+func (ms *MockServer) SetAppendFail(fail bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.appendFail = fail
+}
+
+// SetAppendHang makes Append stop receiving the records until the stream is canceled.
+func (ms *MockServer) SetAppendHang(hang bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.appendHang = hang
+}
+
+// ResetSessions drops all sessions as if the server restarted.
+func (ms *MockServer) ResetSessions() {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.conns = map[string]*MockConn{}
+}
+
 func (ms *MockServer) UserAuth(ctx context.Context, req *machrpc.UserAuthRequest) (*machrpc.UserAuthResponse, error) {
 	auth := true
 	reason := "success"
@@ -87,6 +127,8 @@ func (ms *MockServer) UserAuth(ctx context.Context, req *machrpc.UserAuthRequest
 }
 
 func (ms *MockServer) Conn(ctx context.Context, req *machrpc.ConnRequest) (*machrpc.ConnResponse, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 	if req.User != "sys" || req.Password != "manager" {
 		return &machrpc.ConnResponse{
 			Success: false,
@@ -106,6 +148,8 @@ func (ms *MockServer) Conn(ctx context.Context, req *machrpc.ConnRequest) (*mach
 }
 
 func (ms *MockServer) ConnClose(ctx context.Context, req *machrpc.ConnCloseRequest) (*machrpc.ConnCloseResponse, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 	delete(ms.conns, req.Conn.Handle)
 	return &machrpc.ConnCloseResponse{
 		Success: true,
@@ -115,6 +159,8 @@ func (ms *MockServer) ConnClose(ctx context.Context, req *machrpc.ConnCloseReque
 }
 
 func (ms *MockServer) Ping(ctx context.Context, req *machrpc.PingRequest) (*machrpc.PingResponse, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 	_, ok := ms.conns[req.Conn.Handle]
 	if !ok {
 		return &machrpc.PingResponse{Success: false, Reason: "invalid connection", Elapse: "1ms."}, nil
@@ -147,6 +193,8 @@ func (ms *MockServer) GetServicePorts(ctx context.Context, req *machrpc.ServiceP
 }
 
 func (ms *MockServer) Explain(ctx context.Context, req *machrpc.ExplainRequest) (*machrpc.ExplainResponse, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 	ret := &machrpc.ExplainResponse{Success: true, Reason: "success", Elapse: "1ms."}
 	_, ok := ms.conns[req.Conn.Handle]
 	if !ok {
@@ -164,6 +212,8 @@ func (ms *MockServer) Explain(ctx context.Context, req *machrpc.ExplainRequest)
 }
 
 func (ms *MockServer) Exec(ctx context.Context, req *machrpc.ExecRequest) (*machrpc.ExecResponse, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 	ret := &machrpc.ExecResponse{Success: true, Reason: "success", Elapse: "1ms."}
 	_, ok := ms.conns[req.Conn.Handle]
 	if !ok {
@@ -181,6 +231,8 @@ func (ms *MockServer) Exec(ctx context.Context, req *machrpc.ExecRequest) (*mach
 }
 
 func (ms *MockServer) QueryRow(ctx context.Context, req *machrpc.QueryRowRequest) (*machrpc.QueryRowResponse, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 	ret := &machrpc.QueryRowResponse{Success: true, Reason: "success", Elapse: "1ms."}
 	_, ok := ms.conns[req.Conn.Handle]
 	if !ok {
@@ -190,8 +242,15 @@ func (ms *MockServer) QueryRow(ctx context.Context, req *machrpc.QueryRowRequest
 	switch req.Sql {
 	case `select count(*) from example where name = ?`:
 		ret.Values, _ = machrpc.ConvertAnyToPb([]any{int64(123)})
+		ret.Columns = []*machrpc.Column{
+			{Name: "count(*)", Type: machrpc.ColumnTypeString(machrpc.Int64ColumnType), Size: 8, Length: 0},
+		}
 		ret.Message = "a row selected."
 		ret.RowsAffected = 1
+	case `select count(*) from legacy_example`:
+		// the server that does not report the columns of the row
+		ret.Values, _ = machrpc.ConvertAnyToPb([]any{int64(123)})
+		ret.RowsAffected = 1
 	default:
 		ret.Success, ret.Reason = false, "unknown test case"
 	}
@@ -199,6 +258,13 @@ func (ms *MockServer) QueryRow(ctx context.Context, req *machrpc.QueryRowRequest
 }
 
 func (ms *MockServer) Query(ctx context.Context, req *machrpc.QueryRequest) (*machrpc.QueryResponse, error) {
+	if req.Sql == `select * from slow_table` {
+		// long-running statement that never completes by itself
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 	ret := &machrpc.QueryResponse{Success: true, Reason: "success", Elapse: "1ms."}
 	_, ok := ms.conns[req.Conn.Handle]
 	if !ok {
@@ -209,16 +275,33 @@ func (ms *MockServer) Query(ctx context.Context, req *machrpc.QueryRequest) (*ma
 	switch req.Sql {
 	case `select * from example where name = ?`:
 		ret.RowsHandle = &machrpc.RowsHandle{}
-		if len(params) == 1 && params[0] == "query1" {
+		// query1: a row, query2: multiple batches, legacy: server without RowsFetchStream, nulls: a row of NULL value
+		total := map[any]int{"query1": 1, "query2": 2500, "legacy": 3, "nulls": 1}
+		if len(params) == 1 && total[params[0]] > 0 {
 			ret.RowsHandle = &machrpc.RowsHandle{
-				Handle: "query1#1",
+				Handle: fmt.Sprintf("%s#1", params[0]),
 				Conn:   &machrpc.ConnHandle{Handle: req.Conn.Handle},
 			}
 			ret.RowsAffected = 0
-			ms.rows[ret.RowsHandle.Handle] = &MockRows{}
+			ms.rows[ret.RowsHandle.Handle] = &MockRows{total: total[params[0]], nulls: params[0] == "nulls"}
 		} else {
 			ret.Success, ret.Reason = false, fmt.Sprintf("not implemented %+v", params)
 		}
+	case `select * from LEGACY_TAGDATA where 1=0`:
+		// the columns of the table that the Appender response does not report
+		rowsId := atomic.AddInt32(&ms.counter, 1)
+		ret.RowsHandle = &machrpc.RowsHandle{
+			Handle: fmt.Sprintf("columns#%d", rowsId),
+			Conn:   &machrpc.ConnHandle{Handle: req.Conn.Handle},
+		}
+		ms.rows[ret.RowsHandle.Handle] = &MockRows{}
+	case `select * from error_reason where reason = ?`:
+		// the failure of the reason given by the param
+		ret.Success, ret.Reason = false, fmt.Sprint(params[0])
+	case `select * from no_such_table`:
+		ret.Success, ret.Reason = false, "Table 'NO_SUCH_TABLE' does not exist."
+	case `selec * from example`:
+		ret.Success, ret.Reason = false, "Syntax error: near token (selec)."
 	default:
 		ret.Success, ret.Reason = false, "unknown test case"
 	}
@@ -226,13 +309,19 @@ func (ms *MockServer) Query(ctx context.Context, req *machrpc.QueryRequest) (*ma
 }
 
 func (ms *MockServer) Columns(ctx context.Context, rows *machrpc.RowsHandle) (*machrpc.ColumnsResponse, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 	ret := &machrpc.ColumnsResponse{Success: true, Reason: "success", Elapse: "1ms."}
-	switch rows.Handle {
-	case "query1#1":
+	switch mockRows := ms.rows[rows.Handle]; {
+	case mockRows != nil:
+		valueType := machrpc.ColumnType(machrpc.Float64ColumnType)
+		if mockRows.nulls {
+			valueType = machrpc.Int64ColumnType
+		}
 		ret.Columns = []*machrpc.Column{
 			{Name: "name", Type: machrpc.ColumnTypeString(machrpc.VarcharColumnType), Size: 40, Length: 0},
 			{Name: "time", Type: machrpc.ColumnTypeString(machrpc.DatetimeColumnType), Size: 8, Length: 0},
-			{Name: "value", Type: machrpc.ColumnTypeString(machrpc.Float64ColumnType), Size: 8, Length: 0},
+			{Name: "value", Type: machrpc.ColumnTypeString(valueType), Size: 8, Length: 0},
 		}
 	default:
 		ret.Success, ret.Reason = false, "unknown test case"
@@ -241,6 +330,8 @@ func (ms *MockServer) Columns(ctx context.Context, rows *machrpc.RowsHandle) (*m
 }
 
 func (ms *MockServer) RowsFetch(ctx context.Context, rows *machrpc.RowsHandle) (*machrpc.RowsFetchResponse, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 	mockRows, ok := ms.rows[rows.Handle]
 	if !ok {
 		return &machrpc.RowsFetchResponse{Success: false, Reason: "invalid rows handle", Elapse: "1ms."}, nil
@@ -249,19 +340,51 @@ func (ms *MockServer) RowsFetch(ctx context.Context, rows *machrpc.RowsHandle) (
 	ret := &machrpc.RowsFetchResponse{Success: true, Reason: "success", Elapse: "1ms."}
 
 	var err error
-	switch rows.Handle {
-	case "query1#1":
-		mockRows.nrow++
-		if mockRows.nrow == 1 {
-			ret.Values, err = machrpc.ConvertAnyToPb([]any{"tag", time.Unix(0, 1), 3.14})
-		} else {
-			ret.HasNoRows = true
-		}
+	mockRows.nrow++
+	if mockRows.nrow <= mockRows.total {
+		ret.Values, err = machrpc.ConvertAnyToPb(mockRows.values())
+	} else {
+		ret.HasNoRows = true
 	}
 	return ret, err
 }
 
+func (ms *MockServer) RowsFetchStream(req *machrpc.RowsFetchRequest, stream machrpc.Machbase_RowsFetchStreamServer) error {
+	if strings.HasPrefix(req.Rows.Handle, "legacy#") {
+		return status.Error(codes.Unimplemented, "method RowsFetchStream not implemented")
+	}
+	ms.mu.Lock()
+	mockRows, ok := ms.rows[req.Rows.Handle]
+	ms.mu.Unlock()
+	if !ok {
+		return stream.Send(&machrpc.RowsFetchBatch{Success: false, Reason: "invalid rows handle", Elapse: "1ms."})
+	}
+	for {
+		ret := &machrpc.RowsFetchBatch{Success: true, Reason: "success", Elapse: "1ms."}
+		for len(ret.Records) < int(req.BatchSize) {
+			mockRows.nrow++
+			if mockRows.nrow > mockRows.total {
+				ret.HasNoRows = true
+				break
+			}
+			values, err := machrpc.ConvertAnyToPb(mockRows.values())
+			if err != nil {
+				return err
+			}
+			ret.Records = append(ret.Records, &machrpc.RowsFetchRecord{Values: values})
+		}
+		if err := stream.Send(ret); err != nil {
+			return err
+		}
+		if ret.HasNoRows {
+			return nil
+		}
+	}
+}
+
 func (ms *MockServer) RowsClose(ctx context.Context, rows *machrpc.RowsHandle) (*machrpc.RowsCloseResponse, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 	if _, ok := ms.rows[rows.Handle]; !ok {
 		return &machrpc.RowsCloseResponse{Success: false, Reason: "invalid rows handle", Elapse: "1ms."}, nil
 	}
@@ -273,10 +396,41 @@ func (ms *MockServer) RowsClose(ctx context.Context, rows *machrpc.RowsHandle) (
 	}, nil
 }
 
+func (ms *MockServer) KillSession(ctx context.Context, req *machrpc.KillSessionRequest) (*machrpc.KillSessionResponse, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, ok := ms.conns[req.Id]; !ok {
+		return &machrpc.KillSessionResponse{Success: false, Reason: "session not found", Elapse: "1ms."}, nil
+	}
+	delete(ms.conns, req.Id)
+	ms.killed = append(ms.killed, req.Id)
+	return &machrpc.KillSessionResponse{Success: true, Reason: "success", Elapse: "1ms."}, nil
+}
+
+// KilledSessions returns the ids of the sessions that are killed by KillSession.
+func (ms *MockServer) KilledSessions() []string {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return append([]string{}, ms.killed...)
+}
+
+// RowsOpened returns true if the rows of the handle are not closed.
+func (ms *MockServer) RowsOpened(handle string) bool {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	_, ok := ms.rows[handle]
+	return ok
+}
+
 func (ms *MockServer) Appender(ctx context.Context, req *machrpc.AppenderRequest) (*machrpc.AppenderResponse, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 	if _, ok := ms.conns[req.Conn.Handle]; !ok {
 		return &machrpc.AppenderResponse{Success: false, Reason: "invalid connection", Elapse: "1ms."}, nil
 	}
+	if ms.appendFail {
+		return &machrpc.AppenderResponse{Success: false, Reason: "mock append failure", Elapse: "1ms."}, nil
+	}
 
 	appenderId := atomic.AddInt32(&ms.counter, 1)
 	appenderHandle := fmt.Sprintf("appender#%d", appenderId)
@@ -294,13 +448,53 @@ func (ms *MockServer) Appender(ctx context.Context, req *machrpc.AppenderRequest
 			Conn:   req.Conn,
 		},
 		TableName: strings.ToUpper(req.TableName),
+		Columns:   mockAppenderColumns(req.TableName),
 	}, nil
 }
 
+// mockAppenderColumns returns the columns of the table, nil for the table
+// that simulates the server that does not report the columns.
+// The columns of "legacy_tagdata" are reported only by the query.
+func mockAppenderColumns(table string) []*machrpc.Column {
+	if table != "tagdata" {
+		return nil
+	}
+	return []*machrpc.Column{
+		{Name: "NAME", Type: machrpc.ColumnTypeString(machrpc.VarcharColumnType), Size: 40},
+		{Name: "TIME", Type: machrpc.ColumnTypeString(machrpc.DatetimeColumnType), Size: 8},
+		{Name: "VALUE", Type: machrpc.ColumnTypeString(machrpc.Float64ColumnType), Size: 8},
+	}
+}
+
+// AppendedRecords returns the records that the last appender of the table received,
+// the records are kept only for the tables "tagdata" and "legacy_tagdata".
+func (ms *MockServer) AppendedRecords(table string) [][]any {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var last *MockAppender
+	var lastId string
+	for id, app := range ms.appenders {
+		if app.table == table && (last == nil || len(id) > len(lastId) || (len(id) == len(lastId) && id > lastId)) {
+			last, lastId = app, id
+		}
+	}
+	if last == nil {
+		return nil
+	}
+	return last.records
+}
+
 func (ms *MockServer) Append(stream machrpc.Machbase_AppendServer) error {
 	tick := time.Now()
 	successCount := int64(0)
 	failCount := int64(0)
+	ms.mu.Lock()
+	hang := ms.appendHang
+	ms.mu.Unlock()
+	if hang {
+		<-stream.Context().Done()
+		return stream.Context().Err()
+	}
 	for {
 		rec, err := stream.Recv()
 		if err != nil {
@@ -315,6 +509,21 @@ func (ms *MockServer) Append(stream machrpc.Machbase_AppendServer) error {
 			}
 			return err
 		}
+		ms.mu.Lock()
+		app := ms.appenders[rec.Handle.Handle]
+		fail := ms.appendFail
+		ms.mu.Unlock()
+		if fail || (app != nil && app.table == "broken") {
+			return status.Error(codes.Internal, "mock append failure")
+		}
+		if app != nil && (app.table == "tagdata" || app.table == "legacy_tagdata") {
+			ms.mu.Lock()
+			for _, r := range rec.Records {
+				values, _ := machrpc.ConvertPbTupleToAny(r.Tuple)
+				app.records = append(app.records, values)
+			}
+			ms.mu.Unlock()
+		}
 		successCount += int64(len(rec.Records))
 	}
 }
@@ -1,6 +1,12 @@
 package machrpc
 
-import "fmt"
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+)
 
 // 0: Log Table, 1: Fixed Table, 3: Volatile Table,
 // 4: Lookup Table, 5: KeyValue Table, 6: Tag Table
@@ -97,3 +103,117 @@ func ColumnTypeString(typ ColumnType) string {
 		return fmt.Sprintf("undef-%d", typ)
 	}
 }
+
+func (typ ColumnType) String() string {
+	return ColumnTypeString(typ)
+}
+
+// ParseColumnType converts the type name that the server reports into ColumnType.
+func ParseColumnType(name string) (ColumnType, error) {
+	switch strings.ToLower(name) {
+	case "int16", "short":
+		return Int16ColumnType, nil
+	case "uint16", "ushort":
+		return Uint16ColumnType, nil
+	case "int32", "int", "integer":
+		return Int32ColumnType, nil
+	case "uint32", "uint", "uinteger":
+		return Uint32ColumnType, nil
+	case "int64", "long":
+		return Int64ColumnType, nil
+	case "uint64", "ulong":
+		return Uint64ColumnType, nil
+	case "float", "float32":
+		return Float32ColumnType, nil
+	case "double", "float64":
+		return Float64ColumnType, nil
+	case "varchar", "string":
+		return VarcharColumnType, nil
+	case "text":
+		return TextColumnType, nil
+	case "clob":
+		return ClobColumnType, nil
+	case "blob":
+		return BlobColumnType, nil
+	case "binary":
+		return BinaryColumnType, nil
+	case "datetime":
+		return DatetimeColumnType, nil
+	case "ipv4":
+		return IpV4ColumnType, nil
+	case "ipv6":
+		return IpV6ColumnType, nil
+	case "json":
+		return JsonColumnType, nil
+	default:
+		return 0, fmt.Errorf("unknown column type %q", name)
+	}
+}
+
+// ScanType returns the Go type that is suitable for scanning the column.
+func (typ ColumnType) ScanType() reflect.Type {
+	switch typ {
+	case Int16ColumnType:
+		return reflect.TypeOf(int16(0))
+	case Uint16ColumnType:
+		return reflect.TypeOf(uint16(0))
+	case Int32ColumnType:
+		return reflect.TypeOf(int32(0))
+	case Uint32ColumnType:
+		return reflect.TypeOf(uint32(0))
+	case Int64ColumnType:
+		return reflect.TypeOf(int64(0))
+	case Uint64ColumnType:
+		return reflect.TypeOf(uint64(0))
+	case Float32ColumnType:
+		return reflect.TypeOf(float32(0))
+	case Float64ColumnType:
+		return reflect.TypeOf(float64(0))
+	case VarcharColumnType, TextColumnType, ClobColumnType, JsonColumnType:
+		return reflect.TypeOf("")
+	case BlobColumnType, BinaryColumnType:
+		return reflect.TypeOf([]byte{})
+	case DatetimeColumnType:
+		return reflect.TypeOf(time.Time{})
+	case IpV4ColumnType, IpV6ColumnType:
+		return reflect.TypeOf(net.IP{})
+	default:
+		return reflect.TypeOf((*any)(nil)).Elem()
+	}
+}
+
+// IsVariableLength returns true if the length of the column value varies.
+func (typ ColumnType) IsVariableLength() bool {
+	switch typ {
+	case VarcharColumnType, TextColumnType, ClobColumnType, BlobColumnType, BinaryColumnType, JsonColumnType:
+		return true
+	default:
+		return false
+	}
+}
+
+// ColumnDescriptor describes a column of the result of a query.
+type ColumnDescriptor struct {
+	Name     string       // column name
+	Type     ColumnType   // column type, 0 if the server reports unknown type
+	TypeName string       // type name that the server reports
+	ScanType reflect.Type // Go type that is suitable for scanning the column
+	Size     int          // size of the column in bytes
+	Length   int          // length of the column that the server reports
+	// Nullable reports whether the column may be NULL.
+	// The server does not report NOT NULL constraint, all columns are considered nullable.
+	Nullable bool
+}
+
+func newColumnDescriptor(c *Column) *ColumnDescriptor {
+	typ, _ := ParseColumnType(c.Type)
+	return &ColumnDescriptor{
+		Name:     c.Name,
+		Type:     typ,
+		TypeName: c.Type,
+		ScanType: typ.ScanType(),
+		Size:     int(c.Size),
+		Length:   int(c.Length),
+		Nullable: true,
+	}
+}
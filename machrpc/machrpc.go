@@ -6,27 +6,78 @@ import (
 	context "context"
 	"database/sql"
 	"fmt"
+	"io"
+	"log/slog"
 	"net"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
 type Config struct {
-	ServerAddr    string
+	ServerAddr string
+	// Endpoints are the addresses of the servers, tcp://ipaddr:port or unix://path.
+	// ServerAddr is the first endpoint if both are specified.
+	Endpoints []string
+	// EndpointPolicy selects the endpoint of a new connection, default is EndpointFailover.
+	EndpointPolicy EndpointPolicy
+	// HealthCheckInterval is the interval of probing the endpoints if there are multiple endpoints.
+	// 0 means DefaultHealthCheckInterval, negative value disables probing.
+	HealthCheckInterval time.Duration
+
 	Tls           *TlsConfig
 	QueryTimeout  time.Duration
 	Appendtimeout time.Duration
-}
-
-type TlsConfig struct {
-	ClientCert string
-	ClientKey  string
-	ServerCert string
-}
+	// Retry is the policy for retrying idempotent calls, nil disables retry.
+	Retry *RetryPolicy
+	// FetchBatchSize is the number of rows that Rows receives in a message.
+	// 0 means DefaultFetchBatchSize, negative value disables batched fetch.
+	FetchBatchSize int
+
+	// Keepalive makes the client send keepalive pings on the idle connection,
+	// so that the connection is not dropped by NAT or firewalls. nil disables keepalive pings.
+	Keepalive *keepalive.ClientParameters
+	// MaxRecvMsgSize is the maximum size of a message that the client receives in bytes,
+	// 0 means the default of gRPC (4MB).
+	MaxRecvMsgSize int
+	// MaxSendMsgSize is the maximum size of a message that the client sends in bytes,
+	// 0 means the default of gRPC.
+	MaxSendMsgSize int
+	// AppendCompression is the name of the compressor of the Append stream, e.g. "gzip".
+	// Empty means no compression.
+	AppendCompression string
+	// UserAgent is prepended to the user-agent of gRPC.
+	UserAgent string
+	// Metadata is sent with every call, nil means DefaultMetadata.
+	Metadata map[string]string
+	// UnaryInterceptors and StreamInterceptors are chained in the order,
+	// they are called after Metadata is added to the outgoing context.
+	UnaryInterceptors  []grpc.UnaryClientInterceptor
+	StreamInterceptors []grpc.StreamClientInterceptor
+	// DialOptions are appended to the options that the client makes from the config.
+	DialOptions []grpc.DialOption
+
+	// Logger logs the statements and the append flushes at the debug level,
+	// the failures at the error level, the unavailable endpoints and the resources
+	// that are left open on Close at the warning level. nil disables logging.
+	Logger *slog.Logger
+	// Hooks are called in the order for the statements and the append flushes, after Logger.
+	Hooks []Hooks
+	// RedactParams rewrites the params of the statements that are passed to the Hooks and Logger,
+	// e.g. RedactAll. nil passes the params as they are.
+	RedactParams func(sqlText string, params []any) []any
+}
+
+// DefaultFetchBatchSize is the default number of rows of a batch.
+const DefaultFetchBatchSize = 1000
 
 // Client is a convenient data type represents client side of machbase-neo.
 //
@@ -35,17 +86,24 @@ type TlsConfig struct {
 // serverAddr can be tcp://ipaddr:port or unix://path.
 // The path of unix domain socket can be absolute/releative path.
 type Client struct {
-	conn grpc.ClientConnInterface
-	cli  MachbaseClient
+	endpoints      []*endpoint
+	endpointPolicy EndpointPolicy
+	nextEndpoint   atomic.Uint32 // index of the endpoint for EndpointRoundRobin and EndpointPickFirst
+	closeCh        chan struct{} // closed by CloseContext to stop the health check
 
 	serverAddr    string
-	serverCert    string
-	certPath      string
-	keyPath       string
 	queryTimeout  time.Duration
 	appendTimeout time.Duration
+	retryPolicy   *RetryPolicy
+	fetchBatch    int
+	appendOpts    []grpc.CallOption // options of the Append stream
+	logger        *slog.Logger      // nil if Config.Logger is not set
+	hooks         []Hooks
+	redactParams  func(sqlText string, params []any) []any
 
-	closeOnce sync.Once
+	resources     map[resource]OpenResource // Conn, Rows and Appender that are not closed
+	resourcesLock sync.Mutex
+	closed        bool
 }
 
 // NewClient creates new instance of Client.
@@ -54,55 +112,85 @@ func NewClient(cfg *Config) (*Client, error) {
 		return nil, errors.New("nil config")
 	}
 	client := &Client{
-		serverAddr:    cfg.ServerAddr,
-		queryTimeout:  cfg.QueryTimeout,
-		appendTimeout: cfg.Appendtimeout,
+		serverAddr:     cfg.ServerAddr,
+		endpointPolicy: cfg.EndpointPolicy,
+		closeCh:        make(chan struct{}),
+		queryTimeout:   cfg.QueryTimeout,
+		appendTimeout:  cfg.Appendtimeout,
+		retryPolicy:    cfg.Retry,
+		fetchBatch:     cfg.FetchBatchSize,
+		logger:         cfg.Logger,
+		redactParams:   cfg.RedactParams,
+	}
+	if client.logger != nil {
+		client.hooks = append(client.hooks, &logHooks{logger: client.logger})
+	}
+	for _, h := range cfg.Hooks {
+		if h != nil {
+			client.hooks = append(client.hooks, h)
+		}
+	}
+	if client.fetchBatch == 0 {
+		client.fetchBatch = DefaultFetchBatchSize
 	}
 
-	if client.serverAddr == "" {
-		return nil, errors.New("server address is not specified")
+	addrs := cfg.Endpoints
+	if client.serverAddr != "" {
+		addrs = append([]string{client.serverAddr}, addrs...)
 	}
-	if cfg.Tls != nil {
-		client.certPath = cfg.Tls.ClientCert
-		client.keyPath = cfg.Tls.ClientKey
-		client.serverCert = cfg.Tls.ServerCert
+	if len(addrs) == 0 {
+		return nil, errors.New("server address is not specified")
 	}
+	client.serverAddr = addrs[0]
 
-	var conn grpc.ClientConnInterface
-	var err error
-
-	if client.keyPath != "" && client.certPath != "" && client.serverCert != "" {
-		conn, err = MakeGrpcTlsConn(client.serverAddr, client.keyPath, client.certPath, client.serverCert)
-	} else {
-		conn, err = MakeGrpcConn(client.serverAddr, nil)
+	creds := insecure.NewCredentials()
+	if cfg.Tls.enabled() {
+		tlsCreds, err := cfg.Tls.credentials()
+		if err != nil {
+			return nil, errors.Wrap(err, "NewClient")
+		}
+		creds = tlsCreds
 	}
-
-	if err != nil {
-		return nil, errors.Wrap(err, "NewClient")
+	if cfg.AppendCompression != "" {
+		client.appendOpts = append(client.appendOpts, grpc.UseCompressor(cfg.AppendCompression))
+	}
+	dialOpts := cfg.dialOptions()
+	for _, addr := range addrs {
+		conn, err := makeGrpcConn(addr, creds, dialOpts...)
+		if err != nil {
+			client.closeEndpoints()
+			return nil, errors.Wrap(err, "NewClient")
+		}
+		client.endpoints = append(client.endpoints, newEndpoint(addr, conn, client.logger))
 	}
-	client.conn = conn
-	client.cli = NewMachbaseClient(conn)
 
+	if interval := cfg.HealthCheckInterval; len(client.endpoints) > 1 && interval >= 0 {
+		if interval == 0 {
+			interval = DefaultHealthCheckInterval
+		}
+		go client.healthCheck(interval)
+	}
 	return client, nil
 }
 
+// Close closes the client and the resources that are still open,
+// it waits up to 10 seconds. Use CloseContext for the other deadline and the leak report.
 func (client *Client) Close() {
-	client.closeOnce.Do(func() {
-		client.conn = nil
-		client.cli = nil
-	})
+	ctx, cancelFunc := context.WithTimeout(context.Background(), defaultCloseTimeout)
+	defer cancelFunc()
+	client.CloseContext(ctx)
 }
 
 func (client *Client) UserAuth(user string, password string) (bool, error) {
 	ctx, cancelFunc := client.queryContext()
 	defer cancelFunc()
 	req := &UserAuthRequest{LoginName: user, Password: password}
-	rsp, err := client.cli.UserAuth(ctx, req)
+	rsp, err := client.activeEndpoint().cli.UserAuth(ctx, req)
 	if err != nil {
-		return false, err
+		return false, wrapError("UserAuth", err)
 	}
 	if !rsp.Success {
-		return false, errors.New(rsp.Reason)
+		return false, newServerError("UserAuth", rsp.Reason, rsp.Elapse)
 	}
 	return true, nil
 }
@@ -112,12 +200,16 @@ func (client *Client) GetServerInfo() (*ServerInfo, error) {
 	ctx, cancelFunc := client.queryContext()
 	defer cancelFunc()
 	req := &ServerInfoRequest{}
-	rsp, err := client.cli.GetServerInfo(ctx, req)
+	var rsp *ServerInfo
+	err := client.retry(ctx, func() (err error) {
+		rsp, err = client.activeEndpoint().cli.GetServerInfo(ctx, req)
+		return
+	})
 	if err != nil {
-		return nil, err
+		return nil, wrapError("GetServerInfo", err)
 	}
 	if !rsp.Success {
-		return nil, errors.New(rsp.Reason)
+		return nil, newServerError("GetServerInfo", rsp.Reason, rsp.Elapse)
 	}
 	return rsp, nil
 }
@@ -126,9 +218,9 @@ func (client *Client) GetServicePorts(svc string) ([]*Port, error) {
 	ctx, cancelFunc := client.queryContext()
 	defer cancelFunc()
 	req := &ServicePortsRequest{Service: svc}
-	rsp, err := client.cli.GetServicePorts(ctx, req)
+	rsp, err := client.activeEndpoint().cli.GetServicePorts(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, wrapError("GetServicePorts", err)
 	}
 
 	return rsp.Ports, nil
@@ -138,9 +230,9 @@ func (client *Client) ServerSessions(reqStatz, reqSessions bool) (*Statz, []*Ses
 	ctx, cancelFunc := client.queryContext()
 	defer cancelFunc()
 	req := &SessionsRequest{Statz: reqStatz, Sessions: reqSessions}
-	rsp, err := client.cli.Sessions(ctx, req)
+	rsp, err := client.activeEndpoint().cli.Sessions(ctx, req)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapError("Sessions", err)
 	}
 	return rsp.Statz, rsp.Sessions, nil
 }
@@ -149,15 +241,15 @@ func (client *Client) ServerKillSession(sessionId string, force bool) (bool, err
 	ctx, cancelFunc := client.queryContext()
 	defer cancelFunc()
 	req := &KillSessionRequest{Id: sessionId, Force: force}
-	rsp, err := client.cli.KillSession(ctx, req)
+	rsp, err := client.activeEndpoint().cli.KillSession(ctx, req)
 	if err != nil {
-		return false, err
+		return false, wrapError("KillSession", err)
 	}
 	return rsp.Success, nil
 }
 
 func (client *Client) queryContext() (context.Context, context.CancelFunc) {
-	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"client": "machrpc"}))
+	ctx := context.Background()
 	cancel := func() {}
 	if client.queryTimeout > 0 {
 		ctx, cancel = context.WithTimeout(ctx, client.queryTimeout)
@@ -165,6 +257,20 @@ func (client *Client) queryContext() (context.Context, context.CancelFunc) {
 	return ctx, cancel
 }
 
+// defaultReleaseTimeout is the timeout of the calls that release the resources of the server
+// after the context of the caller is cancelled, if the client has no QueryTimeout.
+const defaultReleaseTimeout = 5 * time.Second
+
+// detachedContext returns a context that keeps the values of ctx but is not cancelled with ctx.
+// It is used to release the resources of the server after ctx is cancelled.
+func (client *Client) detachedContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := client.queryTimeout
+	if timeout <= 0 {
+		timeout = defaultReleaseTimeout
+	}
+	return context.WithTimeout(context.WithoutCancel(ctx), timeout)
+}
+
 type ConnectOption func(*Conn)
 
 func WithPassword(username string, password string) ConnectOption {
@@ -174,8 +280,23 @@ func WithPassword(username string, password string) ConnectOption {
 	}
 }
 
+// WithKillOnCancel makes the connection kill its session on the server
+// when the context of Exec, Query or QueryRow is cancelled
+// after the statement has been running longer than the threshold.
+// The statement keeps running on the server otherwise, until it completes by itself.
+// The session is re-established on the next call of the connection.
+func WithKillOnCancel(threshold time.Duration) ConnectOption {
+	return func(conn *Conn) {
+		conn.killOnCancel = true
+		conn.killThreshold = threshold
+	}
+}
+
 // Connect make a connection to the server
 func (client *Client) Connect(ctx context.Context, opts ...ConnectOption) (*Conn, error) {
+	if client.isClosed() {
+		return nil, ErrClientClosed
+	}
 	ret := &Conn{client: client}
 	for _, o := range opts {
 		o(ret)
@@ -188,87 +309,181 @@ func (client *Client) Connect(ctx context.Context, opts ...ConnectOption) (*Conn
 	if req.User == "" {
 		return nil, errors.New("no user specified, use WithPassword() option")
 	}
-	rsp, err := client.cli.Conn(ctx, req)
+	ep, rsp, err := client.connect(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, wrapError("Conn", err)
 	}
 
 	if !rsp.Success {
-		return nil, errors.New(rsp.Reason)
+		return nil, newServerError("Conn", rsp.Reason, rsp.Elapse)
+	}
+	// bind before track, the Close of the client can close the tracked conn at once
+	ret.bind(ep, rsp.Conn)
+	if err := client.track(ret, "Conn", rsp.Conn.GetHandle()); err != nil {
+		ep.conns.Add(-1)
+		ep.cli.ConnClose(ctx, &ConnCloseRequest{Conn: rsp.Conn})
+		return nil, err
 	}
-	ret.ctx = ctx
-	ret.handle = rsp.Conn
 	return ret, nil
 }
 
 type Conn struct {
-	ctx    context.Context
 	client *Client
 
-	dbUser     string
-	dbPassword string
+	dbUser        string
+	dbPassword    string
+	killOnCancel  bool
+	killThreshold time.Duration
+
+	handle     *ConnHandle
+	ep         *endpoint // the endpoint of the session
+	closed     bool
+	handleLock sync.Mutex
+	suspect    bool // the previous call failed by a transport error
+	lost       bool // the server lost the session
+	closeOnce  sync.Once
+
+	// used by Pool
+	createdAt  time.Time
+	returnedAt time.Time
+}
 
-	handle    *ConnHandle
-	closeOnce sync.Once
+// Endpoint returns the address of the endpoint that the session of the connection is made to.
+func (conn *Conn) Endpoint() string {
+	conn.handleLock.Lock()
+	defer conn.handleLock.Unlock()
+	return conn.ep.addr
 }
 
+// Close closes the connection, it is bounded by the QueryTimeout of the client.
 func (conn *Conn) Close() error {
+	ctx, cancelFunc := conn.client.queryContext()
+	defer cancelFunc()
+	return conn.CloseContext(ctx)
+}
+
+// CloseContext closes the connection.
+func (conn *Conn) CloseContext(ctx context.Context) error {
 	var err error
 	conn.closeOnce.Do(func() {
+		conn.client.untrack(conn)
+		conn.handleLock.Lock()
 		req := &ConnCloseRequest{Conn: conn.handle}
-		_, err = conn.client.cli.ConnClose(conn.ctx, req)
+		ep := conn.ep
+		ep.conns.Add(-1)
+		conn.closed = true
+		conn.handleLock.Unlock()
+		_, err = ep.cli.ConnClose(ctx, req)
 	})
-	return err
+	return wrapError("ConnClose", err)
 }
 
+// Ping checks the session of the connection, it is bounded by the QueryTimeout of the client.
 func (conn *Conn) Ping() (time.Duration, error) {
+	ctx, cancelFunc := conn.client.queryContext()
+	defer cancelFunc()
+	return conn.PingContext(ctx)
+}
+
+// PingContext checks the session of the connection and returns the round trip time.
+func (conn *Conn) PingContext(ctx context.Context) (time.Duration, error) {
 	tick := time.Now()
-	req := &PingRequest{Conn: conn.handle, Token: tick.UnixNano()}
-	rsp, err := conn.client.cli.Ping(conn.ctx, req)
-	if err != nil {
-		return time.Since(tick), err
+	err := conn.invoke(ctx, true, func(cli MachbaseClient, handle *ConnHandle) error {
+		req := &PingRequest{Conn: handle, Token: tick.UnixNano()}
+		rsp, err := cli.Ping(ctx, req)
+		if err != nil {
+			return err
+		}
+		if !rsp.Success {
+			return newServerError("Ping", rsp.Reason, rsp.Elapse)
+		}
+		return nil
+	})
+	return time.Since(tick), wrapError("Ping", err)
+}
+
+// killCancelled kills the session of the handle if WithKillOnCancel is set
+// and the cancelled statement has been running longer than the threshold since started.
+// It returns true if the session is killed.
+func (conn *Conn) killCancelled(cli MachbaseClient, handle *ConnHandle, started time.Time) bool {
+	if !conn.killOnCancel || handle == nil || time.Since(started) < conn.killThreshold {
+		return false
 	}
-	if !rsp.Success {
-		return time.Since(tick), errors.New(rsp.Reason)
+	ctx, cancelFunc := conn.client.detachedContext(context.Background())
+	defer cancelFunc()
+	rsp, err := cli.KillSession(ctx, &KillSessionRequest{Id: handle.Handle})
+	if err != nil || !rsp.Success {
+		return false
 	}
-	return time.Since(tick), nil
+	conn.markSuspect(handle, true)
+	return true
 }
 
 // Explain retrieve execution plan of the given SQL statement.
 func (conn *Conn) Explain(ctx context.Context, sqlText string, full bool) (string, error) {
-	req := &ExplainRequest{Conn: conn.handle, Sql: sqlText, Full: full}
-	rsp, err := conn.client.cli.Explain(ctx, req)
+	var plan string
+	err := conn.invoke(ctx, true, func(cli MachbaseClient, handle *ConnHandle) error {
+		req := &ExplainRequest{Conn: handle, Sql: sqlText, Full: full}
+		rsp, err := cli.Explain(ctx, req)
+		if err != nil {
+			return err
+		}
+		if !rsp.Success {
+			return newServerError("Explain", rsp.Reason, rsp.Elapse)
+		}
+		plan = rsp.Plan
+		return nil
+	})
 	if err != nil {
-		return "", err
-	}
-	if !rsp.Success {
-		return "", fmt.Errorf(rsp.Reason)
+		return "", wrapError("Explain", err)
 	}
-	return rsp.Plan, nil
+	return plan, nil
 }
 
 // Exec executes SQL statements that does not return result
 // like 'ALTER', 'CREATE TABLE', 'DROP TABLE', ...
 func (conn *Conn) Exec(ctx context.Context, sqlText string, params ...any) *Result {
+	evt := conn.client.traceStart(ctx, "Exec", sqlText, params)
+	result := conn.exec(ctx, sqlText, params)
+	conn.client.traceEnd(ctx, evt, result.rowsAffected, result.elapse, result.err)
+	return result
+}
+
+func (conn *Conn) exec(ctx context.Context, sqlText string, params []any) *Result {
 	pbparams, err := ConvertAnyToPb(params)
 	if err != nil {
 		return &Result{err: err}
 	}
-	req := &ExecRequest{Conn: conn.handle, Sql: sqlText, Params: pbparams}
-	rsp, err := conn.client.cli.Exec(ctx, req)
+	var rsp *ExecResponse
+	var usedCli MachbaseClient
+	var used *ConnHandle
+	started := time.Now()
+	err = conn.invoke(ctx, false, func(cli MachbaseClient, handle *ConnHandle) error {
+		usedCli, used = cli, handle
+		req := &ExecRequest{Conn: handle, Sql: sqlText, Params: pbparams}
+		rsp, err = cli.Exec(ctx, req)
+		if err == nil && !rsp.Success {
+			err = newServerError("Exec", rsp.Reason, rsp.Elapse)
+		}
+		return err
+	})
 	if err != nil {
-		return &Result{err: err}
-	}
-	if !rsp.Success {
-		return &Result{err: errors.New(rsp.Reason), message: rsp.Reason}
+		if ctx.Err() != nil {
+			conn.killCancelled(usedCli, used, started)
+		}
+		if rsp != nil && !rsp.Success {
+			return &Result{err: err, message: rsp.Reason, elapse: rsp.Elapse}
+		}
+		return &Result{err: wrapError("Exec", err)}
 	}
-	return &Result{message: rsp.Reason, rowsAffected: rsp.RowsAffected}
+	return &Result{message: rsp.Reason, rowsAffected: rsp.RowsAffected, elapse: rsp.Elapse}
 }
 
 type Result struct {
 	err          error
 	rowsAffected int64
 	message      string
+	elapse       string // elapsed time reported by the server
 }
 
 func (r *Result) Err() error {
@@ -297,51 +512,133 @@ func (r *Result) Message() string {
 //	}
 //	defer rows.Close()
 func (conn *Conn) Query(ctx context.Context, sqlText string, params ...any) (*Rows, error) {
+	evt := conn.client.traceStart(ctx, "Query", sqlText, params)
+	rows, err := conn.query(ctx, sqlText, params)
+	if evt != nil {
+		if err != nil {
+			conn.client.traceEnd(ctx, evt, 0, serverElapse(err), err)
+		} else {
+			conn.client.traceEnd(ctx, evt, rows.rowsAffected, rows.elapse, nil)
+		}
+	}
+	return rows, err
+}
+
+func (conn *Conn) query(ctx context.Context, sqlText string, params []any) (*Rows, error) {
 	pbparams, err := ConvertAnyToPb(params)
 	if err != nil {
 		return nil, err
 	}
 
-	req := &QueryRequest{Conn: conn.handle, Sql: sqlText, Params: pbparams}
-	rsp, err := conn.client.cli.Query(ctx, req)
+	var rsp *QueryResponse
+	var usedCli MachbaseClient
+	var used *ConnHandle
+	started := time.Now()
+	err = conn.invoke(ctx, false, func(cli MachbaseClient, handle *ConnHandle) error {
+		usedCli, used = cli, handle
+		req := &QueryRequest{Conn: handle, Sql: sqlText, Params: pbparams}
+		rsp, err = cli.Query(ctx, req)
+		if err == nil && !rsp.Success && isSessionLost(errors.New(rsp.Reason)) {
+			err = newServerError("Query", rsp.Reason, rsp.Elapse)
+		}
+		return err
+	})
 	if err != nil {
-		return nil, err
+		if ctx.Err() != nil {
+			conn.killCancelled(usedCli, used, started)
+		}
+		return nil, wrapError("Query", err)
 	}
 
 	if rsp.Success {
-		return &Rows{
+		rows := &Rows{
 			ctx:          ctx,
 			client:       conn.client,
+			cli:          usedCli,
+			conn:         conn,
+			connHandle:   used,
+			started:      started,
 			rowsAffected: rsp.RowsAffected,
 			message:      rsp.Reason,
+			elapse:       rsp.Elapse,
 			handle:       rsp.RowsHandle,
-		}, nil
-	} else {
-		if len(rsp.Reason) > 0 {
-			return nil, errors.New(rsp.Reason)
+			fetchBatch:   conn.client.fetchBatch,
+		}
+		if err := conn.client.track(rows, "Rows", rows.handle.GetHandle()); err != nil {
+			rows.release()
+			return nil, err
 		}
-		return nil, errors.New("unknown error")
+		if ctx.Done() != nil {
+			rows.stopCancel = context.AfterFunc(ctx, rows.cancelled)
+		}
+		return rows, nil
+	} else {
+		return nil, newServerError("Query", rsp.Reason, rsp.Elapse)
 	}
 }
 
 type Rows struct {
 	ctx          context.Context
 	client       *Client
+	cli          MachbaseClient // the endpoint that executed the query
+	conn         *Conn
+	connHandle   *ConnHandle // handle of the connection that executed the query
+	started      time.Time
 	message      string
 	rowsAffected int64
+	elapse       string // elapsed time of the query reported by the server
 	handle       *RowsHandle
 	values       []any
 	err          error
 	closeOnce    sync.Once
+	closeErr     error
+	stopCancel   func() bool // stops calling cancelled when the context of the query is done
+
+	columns     []*Column // cached result of Columns RPC
+	columnNames []string  // used by ScanStruct
+	columnTypes []*ColumnDescriptor
+
+	fetchBatch   int // batch size of RowsFetchStream, per-row fetch if it is less than 1
+	stream       Machbase_RowsFetchStreamClient
+	streamCancel context.CancelFunc
+	streamEOF    bool
+	batch        []*RowsFetchRecord
 }
 
 // Close release all resources that assigned to the Rows
 func (rows *Rows) Close() error {
-	var err error
+	if rows.stopCancel != nil {
+		rows.stopCancel()
+	}
+	if rows.streamCancel != nil {
+		rows.streamCancel()
+	}
 	rows.closeOnce.Do(func() {
-		_, err = rows.client.cli.RowsClose(rows.ctx, rows.handle)
+		rows.closeErr = rows.release()
 	})
-	return err
+	return rows.closeErr
+}
+
+// cancelled releases the Rows on the server when the context of the query is done before Close,
+// so that the statement does not remain on the server.
+func (rows *Rows) cancelled() {
+	rows.closeOnce.Do(func() {
+		if rows.conn.killCancelled(rows.cli, rows.connHandle, rows.started) {
+			// the rows are released with the session
+			rows.client.untrack(rows)
+			return
+		}
+		rows.release()
+	})
+}
+
+func (rows *Rows) release() error {
+	rows.client.untrack(rows)
+	// rows.ctx may be cancelled already
+	ctx, cancelFunc := rows.client.detachedContext(rows.ctx)
+	defer cancelFunc()
+	_, err := rows.cli.RowsClose(ctx, rows.handle)
+	return wrapError("RowsClose", err)
 }
 
 // IsFetchable returns true if statement that produced this Rows was fetch-able (e.g was select?)
@@ -359,25 +656,54 @@ func (rows *Rows) RowsAffected() int64 {
 
 // Columns returns list of column info that consists of result of query statement.
 func (rows *Rows) Columns() ([]string, []string, error) {
-	rsp, err := rows.client.cli.Columns(rows.ctx, rows.handle)
-	if err != nil {
+	if err := rows.fetchColumns(); err != nil {
 		return nil, nil, err
 	}
-	if rsp.Success {
-		names := make([]string, len(rsp.Columns))
-		types := make([]string, len(rsp.Columns))
-		for i, c := range rsp.Columns {
-			names[i] = c.Name
-			types[i] = c.Type
+	names := make([]string, len(rows.columns))
+	types := make([]string, len(rows.columns))
+	for i, c := range rows.columns {
+		names[i] = c.Name
+		types[i] = c.Type
+	}
+	return names, types, nil
+}
+
+// ColumnTypes returns descriptors of the columns that consists of result of query statement.
+// The descriptors are retrieved from the server once and cached.
+func (rows *Rows) ColumnTypes() ([]*ColumnDescriptor, error) {
+	if rows.columnTypes == nil {
+		if err := rows.fetchColumns(); err != nil {
+			return nil, err
 		}
-		return names, types, nil
-	} else {
+		types := make([]*ColumnDescriptor, len(rows.columns))
+		for i, c := range rows.columns {
+			types[i] = newColumnDescriptor(c)
+		}
+		rows.columnTypes = types
+	}
+	return rows.columnTypes, nil
+}
+
+func (rows *Rows) fetchColumns() error {
+	if rows.columns != nil {
+		return nil
+	}
+	rsp, err := rows.cli.Columns(rows.ctx, rows.handle)
+	if err != nil {
+		return wrapError("Columns", err)
+	}
+	if !rsp.Success {
 		if len(rsp.Reason) > 0 {
-			return nil, nil, errors.New(rsp.Reason)
+			return newServerError("Columns", rsp.Reason, rsp.Elapse)
 		} else {
-			return nil, nil, fmt.Errorf("fail to get columns info")
+			return newServerError("Columns", "fail to get columns info", rsp.Elapse)
 		}
 	}
+	if rsp.Columns == nil {
+		rsp.Columns = []*Column{}
+	}
+	rows.columns = rsp.Columns
+	return nil
 }
 
 // Next returns true if there are at least one more record that can be fetchable
@@ -392,9 +718,31 @@ func (rows *Rows) Next() bool {
 	if rows.err != nil {
 		return false
 	}
-	rsp, err := rows.client.cli.RowsFetch(rows.ctx, rows.handle)
+	if err := rows.ctx.Err(); err != nil {
+		rows.err = wrapError("RowsFetch", err)
+		rows.values = nil
+		return false
+	}
+	for rows.fetchBatch > 0 {
+		if len(rows.batch) > 0 {
+			rows.values = ConvertPbToAny(rows.batch[0].Values)
+			rows.batch[0] = nil
+			rows.batch = rows.batch[1:]
+			return true
+		}
+		if rows.streamEOF {
+			rows.values = nil
+			return false
+		}
+		// fetchBatch is reset to 0 if the server does not support batched fetch
+		if rows.fetchNextBatch(); rows.err != nil {
+			rows.values = nil
+			return false
+		}
+	}
+	rsp, err := rows.cli.RowsFetch(rows.ctx, rows.handle)
 	if err != nil {
-		rows.err = err
+		rows.err = wrapError("RowsFetch", err)
 		return false
 	}
 	if rsp.Success {
@@ -404,13 +752,59 @@ func (rows *Rows) Next() bool {
 		rows.values = ConvertPbToAny(rsp.Values)
 	} else {
 		if len(rsp.Reason) > 0 {
-			rows.err = errors.New(rsp.Reason)
+			rows.err = newServerError("RowsFetch", rsp.Reason, rsp.Elapse)
 		}
 		rows.values = nil
 	}
 	return !rsp.HasNoRows
 }
 
+// fetchNextBatch receives the next batch of rows from the stream.
+// It disables batched fetch if the server does not support RowsFetchStream.
+func (rows *Rows) fetchNextBatch() {
+	if rows.stream == nil {
+		ctx, cancel := context.WithCancel(rows.ctx)
+		stream, err := rows.cli.RowsFetchStream(ctx, &RowsFetchRequest{Rows: rows.handle, BatchSize: int32(rows.fetchBatch)})
+		if err != nil {
+			cancel()
+			rows.err = wrapError("RowsFetchStream", err)
+			return
+		}
+		rows.stream, rows.streamCancel = stream, cancel
+	}
+	rsp, err := rows.stream.Recv()
+	if err != nil {
+		if err == io.EOF {
+			rows.streamEOF = true
+		} else if status.Code(err) == codes.Unimplemented {
+			// older server
+			rows.streamCancel()
+			rows.stream, rows.streamCancel = nil, nil
+			rows.fetchBatch = 0
+		} else {
+			rows.err = wrapError("RowsFetchStream", err)
+		}
+		return
+	}
+	if !rsp.Success {
+		if len(rsp.Reason) > 0 {
+			rows.err = newServerError("RowsFetchStream", rsp.Reason, rsp.Elapse)
+		} else {
+			rows.err = newServerError("RowsFetchStream", "fail to fetch rows", rsp.Elapse)
+		}
+		return
+	}
+	rows.batch = rsp.Records
+	if rsp.HasNoRows {
+		rows.streamEOF = true
+	}
+}
+
+// Err returns the error, if any, that was encountered during iteration.
+func (rows *Rows) Err() error {
+	return rows.err
+}
+
 // Scan retrieve values of columns
 //
 //	for rows.Next(){
@@ -434,20 +828,42 @@ func (rows *Rows) Scan(cols ...any) error {
 //	row := client.QueryRow(ctx, "select count(*) from my_table where name = ?", "my_name")
 //	row.Scan(&cnt)
 func (conn *Conn) QueryRow(ctx context.Context, sqlText string, params ...any) *Row {
+	evt := conn.client.traceStart(ctx, "QueryRow", sqlText, params)
+	row := conn.queryRow(ctx, sqlText, params)
+	conn.client.traceEnd(ctx, evt, row.rowsAffected, row.elapse, row.err)
+	return row
+}
+
+func (conn *Conn) queryRow(ctx context.Context, sqlText string, params []any) *Row {
 	pbparams, err := ConvertAnyToPb(params)
 	if err != nil {
 		return &Row{success: false, err: err}
 	}
 
-	req := &QueryRowRequest{Conn: conn.handle, Sql: sqlText, Params: pbparams}
-	rsp, err := conn.client.cli.QueryRow(ctx, req)
+	var rsp *QueryRowResponse
+	var usedCli MachbaseClient
+	var used *ConnHandle
+	started := time.Now()
+	err = conn.invoke(ctx, true, func(cli MachbaseClient, handle *ConnHandle) error {
+		usedCli, used = cli, handle
+		req := &QueryRowRequest{Conn: handle, Sql: sqlText, Params: pbparams}
+		rsp, err = cli.QueryRow(ctx, req)
+		if err == nil && !rsp.Success && isSessionLost(errors.New(rsp.Reason)) {
+			err = newServerError("QueryRow", rsp.Reason, rsp.Elapse)
+		}
+		return err
+	})
 	if err != nil {
-		return &Row{success: false, err: err}
+		if ctx.Err() != nil {
+			conn.killCancelled(usedCli, used, started)
+		}
+		return &Row{success: false, err: wrapError("QueryRow", err)}
 	}
 
 	var row = &Row{}
 	row.success = rsp.Success
 	row.rowsAffected = rsp.RowsAffected
+	row.elapse = rsp.Elapse
 	if rsp.Message == "" {
 		row.message = rsp.Reason
 	} else {
@@ -455,9 +871,15 @@ func (conn *Conn) QueryRow(ctx context.Context, sqlText string, params ...any) *
 	}
 	row.err = nil
 	if !rsp.Success && len(rsp.Reason) > 0 {
-		row.err = errors.New(rsp.Reason)
+		row.err = newServerError("QueryRow", rsp.Reason, rsp.Elapse)
 	}
 	row.values = ConvertPbToAny(rsp.Values)
+	if len(rsp.Columns) > 0 {
+		row.columns = make([]string, len(rsp.Columns))
+		for i, c := range rsp.Columns {
+			row.columns[i] = c.Name
+		}
+	}
 	return row
 }
 
@@ -465,9 +887,11 @@ type Row struct {
 	success bool
 	err     error
 	values  []any
+	columns []string // names of columns, nil if the server does not report
 
 	rowsAffected int64
 	message      string
+	elapse       string // elapsed time reported by the server
 }
 
 func (row *Row) Success() bool {
@@ -506,218 +930,77 @@ func scan(src []any, dst []any) error {
 		if i >= len(src) {
 			return fmt.Errorf("column %d is out of range %d", i, len(src))
 		}
-		if src[i] == nil {
-			dst[i] = nil
-			continue
-		}
-		var err error
-		switch v := src[i].(type) {
-		default:
-			return fmt.Errorf("column %d is %T, not compatible with %T", i, v, dst[i])
-		case *int:
-			err = ScanInt32(int32(*v), dst[i])
-		case *int16:
-			err = ScanInt16(*v, dst[i])
-		case *int32:
-			err = ScanInt32(*v, dst[i])
-		case *int64:
-			err = ScanInt64(*v, dst[i])
-		case *time.Time:
-			err = ScanDateTime(*v, dst[i])
-		case *float32:
-			err = ScanFloat32(*v, dst[i])
-		case *float64:
-			err = ScanFloat64(*v, dst[i])
-		case *net.IP:
-			err = ScanIP(*v, dst[i])
-		case *string:
-			err = ScanString(*v, dst[i])
-		case []byte:
-			err = ScanBytes(v, dst[i])
-		case int:
-			err = ScanInt32(int32(v), dst[i])
-		case int16:
-			err = ScanInt16(v, dst[i])
-		case int32:
-			err = ScanInt32(v, dst[i])
-		case int64:
-			err = ScanInt64(v, dst[i])
-		case time.Time:
-			err = ScanDateTime(v, dst[i])
-		case float32:
-			err = ScanFloat32(v, dst[i])
-		case float64:
-			err = ScanFloat64(v, dst[i])
-		case net.IP:
-			err = ScanIP(v, dst[i])
-		case string:
-			err = ScanString(v, dst[i])
-		}
-		if err != nil {
-			return err
+		if err := scanValue(src[i], dst[i]); err != nil {
+			return fmt.Errorf("column %d: %w", i, err)
 		}
 	}
 	return nil
 }
 
-type AppenderOption func(*Appender)
-
-// Appender creates a new Appender for the given table.
-// Appender should be closed otherwise it may cause server side resource leak.
-//
-//	app, _ := client.Appender(ctx, "MYTABLE")
-//	defer app.Close()
-//	app.Append("name", time.Now(), 3.14)
-func (conn *Conn) Appender(ctx context.Context, tableName string, opts ...AppenderOption) (*Appender, error) {
-
-	ap := &Appender{
-		ctx:             ctx,
-		timeformat:      "ns",
-		bufferThreshold: 400,
-	}
-
-	for _, opt := range opts {
-		opt(ap)
-	}
-
-	openRsp, err := conn.client.cli.Appender(ctx, &AppenderRequest{
-		Conn:       conn.handle,
-		TableName:  tableName,
-		Timeformat: ap.timeformat,
-	})
-	if err != nil {
-		return nil, errors.Wrap(err, "Appender")
-	}
-
-	if !openRsp.Success {
-		return nil, errors.New(openRsp.Reason)
-	}
-
-	appendClient, err := conn.client.cli.Append(context.Background())
-	if err != nil {
-		return nil, errors.Wrap(err, "AppendClient")
-	}
-
-	ap.client = conn.client
-	ap.appendClient = appendClient
-	ap.tableName = openRsp.TableName
-	ap.tableType = TableType(openRsp.TableType)
-	ap.handle = openRsp.Handle
-
-	ap.bufferTicker = time.NewTicker(time.Second)
-	go func() {
-		for range ap.bufferTicker.C {
-			ap.flush(nil)
-		}
-	}()
-
-	return ap, nil
-}
-
-func AppenderTimeformat(timeformat string) AppenderOption {
-	return func(a *Appender) {
-		a.timeformat = timeformat
-	}
-}
-
-func AppenderBufferThreshold(threshold int) AppenderOption {
-	return func(a *Appender) {
-		a.bufferThreshold = threshold
-	}
-}
-
-type Appender struct {
-	ctx          context.Context
-	client       *Client
-	appendClient Machbase_AppendClient
-	tableName    string
-	tableType    TableType
-	handle       *AppenderHandle
-	timeformat   string
-
-	buffer       []*AppendRecord
-	bufferLock   sync.Mutex
-	bufferTicker *time.Ticker
-
-	bufferThreshold int
-}
-
-// Close releases all resources that allocated to the Appender
-func (appender *Appender) Close() (int64, int64, error) {
-	if appender.appendClient == nil {
-		return 0, 0, nil
-	}
-
-	if appender.bufferTicker != nil {
-		appender.bufferTicker.Stop()
-	}
-	appender.flush(nil)
-
-	client := appender.appendClient
-	appender.appendClient = nil
-
-	done, err := client.CloseAndRecv()
-	if done != nil {
-		return done.SuccessCount, done.FailCount, err
-	} else {
-		return 0, 0, err
-	}
-}
-
-func (appender *Appender) TableName() string {
-	return appender.tableName
-}
-
-func (appender *Appender) TableType() TableType {
-	return appender.tableType
-}
-
-func (appender *Appender) AppendWithTimestamp(ts time.Time, cols ...any) error {
-	return appender.Append(append([]any{ts}, cols...))
-}
-
-// Append appends a new record of the table.
-func (appender *Appender) Append(cols ...any) error {
-	if appender.appendClient == nil {
-		return sql.ErrTxDone
+// scanValue converts src value into dst.
+// NULL is scanned into sql.Scanner, pointer to pointer and *any, otherwise it is an error.
+func scanValue(src any, dst any) error {
+	if isNullValue(src) {
+		return ScanNull(dst)
 	}
-
-	params, err := ConvertAnyToPbTuple(cols)
-	if err != nil {
-		return err
-	}
-	err = appender.flush(&AppendRecord{Tuple: params})
-	return err
-}
-
-func (appender *Appender) Columns() ([]string, []string, error) {
-	return nil, nil, errors.New("rpc appender doesn't implment Columns()")
-}
-
-// force flush if rec is nil
-// allow buffering if rec is not nil
-func (appender *Appender) flush(rec *AppendRecord) error {
-	appender.bufferLock.Lock()
-	defer appender.bufferLock.Unlock()
-
-	if rec != nil {
-		appender.buffer = append(appender.buffer, rec)
-	}
-	if len(appender.buffer) == 0 {
+	switch dv := dst.(type) {
+	case sql.Scanner:
+		return dv.Scan(driverValue(src))
+	case *any:
+		*dv = src
 		return nil
 	}
-
-	if rec != nil && len(appender.buffer) < appender.bufferThreshold {
-		// write new record, but not enough to flush to network
+	if rv := reflect.ValueOf(dst); rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Pointer {
+		// pointer to pointer, e.g. **int
+		elem := reflect.New(rv.Elem().Type().Elem())
+		if err := scanValue(src, elem.Interface()); err != nil {
+			return err
+		}
+		rv.Elem().Set(elem)
 		return nil
 	}
-
-	err := appender.appendClient.Send(&AppendData{
-		Handle:  appender.handle,
-		Records: appender.buffer,
-	})
-	if err == nil {
-		appender.buffer = appender.buffer[:0]
+	var err error
+	switch v := src.(type) {
+	default:
+		return fmt.Errorf("%T is not compatible with %T", v, dst)
+	case *int:
+		err = ScanInt32(int32(*v), dst)
+	case *int16:
+		err = ScanInt16(*v, dst)
+	case *int32:
+		err = ScanInt32(*v, dst)
+	case *int64:
+		err = ScanInt64(*v, dst)
+	case *time.Time:
+		err = ScanDateTime(*v, dst)
+	case *float32:
+		err = ScanFloat32(*v, dst)
+	case *float64:
+		err = ScanFloat64(*v, dst)
+	case *net.IP:
+		err = ScanIP(*v, dst)
+	case *string:
+		err = ScanString(*v, dst)
+	case []byte:
+		err = ScanBytes(v, dst)
+	case int:
+		err = ScanInt32(int32(v), dst)
+	case int16:
+		err = ScanInt16(v, dst)
+	case int32:
+		err = ScanInt32(v, dst)
+	case int64:
+		err = ScanInt64(v, dst)
+	case time.Time:
+		err = ScanDateTime(v, dst)
+	case float32:
+		err = ScanFloat32(v, dst)
+	case float64:
+		err = ScanFloat64(v, dst)
+	case net.IP:
+		err = ScanIP(v, dst)
+	case string:
+		err = ScanString(v, dst)
 	}
 	return err
 }
@@ -0,0 +1,890 @@
+package machrpc
+
+import (
+	context "context"
+	"database/sql"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrBufferFull is returned by Append when the buffer of the Appender is full
+// and the BufferFullPolicy is BufferFullError.
+var ErrBufferFull = errors.New("appender buffer is full")
+
+// BufferFullPolicy decides what Append does when the buffer of the Appender is full.
+type BufferFullPolicy int
+
+const (
+	// BufferFullError makes Append return ErrBufferFull (default).
+	BufferFullError BufferFullPolicy = iota
+	// BufferFullBlock blocks Append until the buffer has room or the context is done.
+	BufferFullBlock
+	// BufferFullDropOldest discards the oldest buffered records to make room for the new one.
+	BufferFullDropOldest
+	// BufferFullDropNewest discards the new record.
+	BufferFullDropNewest
+)
+
+type AppenderOption func(*Appender)
+
+// Appender creates a new Appender for the given table.
+// Appender should be closed otherwise it may cause server side resource leak.
+// When the ctx is done, the background flush stops and Append returns the error of the ctx,
+// Close is still required to send the buffered records and release the resources.
+//
+//	app, _ := client.Appender(ctx, "MYTABLE")
+//	defer app.Close()
+//	app.Append("name", time.Now(), 3.14)
+func (conn *Conn) Appender(ctx context.Context, tableName string, opts ...AppenderOption) (*Appender, error) {
+
+	ap := &Appender{
+		ctx:             ctx,
+		timeformat:      "ns",
+		bufferThreshold: 400,
+		bufferFreed:     make(chan struct{}),
+		flushInterval:   time.Second,
+		closeCh:         make(chan struct{}),
+		workerDone:      make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(ap)
+	}
+
+	ap.conn = conn
+	ap.client = conn.client
+	ap.tableName = tableName
+	ap.timeConv = newTimeConverter(ap.timeformat, ap.timeLocation)
+	if err := ap.open(); err != nil {
+		return nil, err
+	}
+
+	if ap.spoolConfig != nil {
+		sp, err := openSpool(*ap.spoolConfig, ap.tableName, ap.spoolSubdir)
+		if err != nil {
+			ap.appendClient.CloseSend()
+			ap.abortStream()
+			return nil, err
+		}
+		ap.spool = sp
+		if !sp.Empty() {
+			// replay the records that were spooled by the previous appender
+			ap.bufferLock.Lock()
+			err := ap.sendBuffer()
+			ap.unlockBuffer()
+			if err != nil {
+				ap.appendClient.CloseSend()
+				ap.abortStream()
+				sp.Close()
+				return nil, err
+			}
+		}
+	}
+
+	if err := ap.client.track(ap, "Appender", ap.tableName); err != nil {
+		ap.appendClient.CloseSend()
+		ap.abortStream()
+		if ap.spool != nil {
+			ap.spool.Close()
+		}
+		return nil, err
+	}
+
+	if ap.flushInterval > 0 {
+		go ap.worker()
+	} else {
+		close(ap.workerDone)
+	}
+
+	return ap, nil
+}
+
+// worker flushes the buffer every flushInterval until the Appender is closed or the ctx is done.
+func (appender *Appender) worker() {
+	defer close(appender.workerDone)
+	ticker := time.NewTicker(appender.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-appender.closeCh:
+			return
+		case <-appender.ctx.Done():
+			return
+		case <-ticker.C:
+			appender.flush(nil)
+		}
+	}
+}
+
+// open opens the appender of the table with the timeformat and the append stream.
+// If the columns of the table are known, the client converts the values of the datetime columns
+// into the datetime datums that the server takes regardless of the timeformat,
+// otherwise the server converts them according to the timeformat.
+func (appender *Appender) open() error {
+	openRsp, err := appender.openAppender()
+	if err != nil {
+		return err
+	}
+
+	// the stream outlives the calls, Flush aborts it by the cancel when its ctx is done
+	streamCtx, cancel := context.WithCancel(context.Background())
+	appendClient, err := appender.cli.Append(streamCtx, appender.client.appendOpts...)
+	if err != nil {
+		cancel()
+		return errors.Wrap(wrapError("Append", err), "AppendClient")
+	}
+
+	appender.appendClient = appendClient
+	appender.setStreamCancel(cancel)
+	if appender.cols == nil {
+		// the first open, the table does not change on reopen,
+		// so that the name and the type are read without the lock
+		appender.tableName = openRsp.TableName
+		appender.tableType = TableType(openRsp.TableType)
+	}
+	appender.handle = openRsp.Handle
+	columns := openRsp.Columns
+	if columns == nil {
+		if appender.cols != nil {
+			// reopen, the columns were queried already
+			columns = appender.cols.columns
+		} else {
+			columns = appender.queryColumns(openRsp.TableName)
+		}
+	}
+	// the caller holds bufferLock on reopen, the readers take a snapshot by tableColumns
+	appender.cols = newAppenderColumns(columns)
+	appender.streamErr = nil
+	return nil
+}
+
+// queryColumns queries the columns of the table for the server that does not report them
+// in the Appender response, it returns nil if the query fails.
+func (appender *Appender) queryColumns(table string) []*Column {
+	rows, err := appender.conn.query(appender.ctx, fmt.Sprintf("select * from %s where 1=0", table), nil)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	if err := rows.fetchColumns(); err != nil {
+		return nil
+	}
+	return rows.columns
+}
+
+func (appender *Appender) openAppender() (*AppenderResponse, error) {
+	conn := appender.conn
+	var openRsp *AppenderResponse
+	err := conn.invoke(appender.ctx, false, func(cli MachbaseClient, handle *ConnHandle) (err error) {
+		appender.cli = cli
+		openRsp, err = cli.Appender(appender.ctx, &AppenderRequest{
+			Conn:       handle,
+			TableName:  appender.tableName,
+			Timeformat: appender.timeformat,
+		})
+		if err == nil && !openRsp.Success && isSessionLost(errors.New(openRsp.Reason)) {
+			err = newServerError("Appender", openRsp.Reason, openRsp.Elapse)
+		}
+		return
+	})
+	if err != nil {
+		return nil, errors.Wrap(wrapError("Appender", err), "Appender")
+	}
+
+	if !openRsp.Success {
+		return nil, newServerError("Appender", openRsp.Reason, openRsp.Elapse)
+	}
+	return openRsp, nil
+}
+
+// AppenderTimeformat sets the format of the values of the datetime columns (default "ns").
+// It is one of "s", "ms", "us" and "ns" for the epoch integers (or numeric strings),
+// "RFC3339", "RFC3339Nano", or the layout of time.Parse for the strings.
+// time.Time values are accepted regardless of the format.
+// The values that can not be converted are rejected by Append.
+func AppenderTimeformat(timeformat string) AppenderOption {
+	return func(a *Appender) {
+		a.timeformat = timeformat
+	}
+}
+
+// AppenderTimeLocation sets the location of the time strings that have no time zone (default UTC).
+func AppenderTimeLocation(loc *time.Location) AppenderOption {
+	return func(a *Appender) {
+		a.timeLocation = loc
+	}
+}
+
+// AppenderBufferThreshold sets the number of buffered records that triggers a flush (default 400).
+func AppenderBufferThreshold(threshold int) AppenderOption {
+	return func(a *Appender) {
+		a.bufferThreshold = threshold
+	}
+}
+
+// AppenderFlushInterval sets the interval of the background flush (default 1s).
+// If d <= 0, the buffer is flushed only by the thresholds, Flush and Close.
+func AppenderFlushInterval(d time.Duration) AppenderOption {
+	return func(a *Appender) {
+		a.flushInterval = d
+	}
+}
+
+// AppenderFlushBytes sets the total encoded size of buffered records in bytes that triggers a flush.
+// If n <= 0, the size does not trigger a flush (default).
+func AppenderFlushBytes(n int64) AppenderOption {
+	return func(a *Appender) {
+		a.flushBytes = n
+	}
+}
+
+// AppenderMaxBuffer limits the buffer of the Appender by the number of records
+// and the total encoded size of records in bytes, 0 means unlimited.
+// Records are buffered beyond AppenderBufferThreshold only when they can not be sent,
+// the policy decides what Append does when the buffer is full.
+// Without the limit, Append returns ErrSpoolFull when the spool can not take the records
+// while they are kept in the buffer.
+//
+//	app, _ := conn.Appender(ctx, "example",
+//		machrpc.AppenderMaxBuffer(10000, 0, machrpc.BufferFullDropOldest))
+func AppenderMaxBuffer(maxRecords int, maxBytes int64, policy BufferFullPolicy) AppenderOption {
+	return func(a *Appender) {
+		a.maxBufferRecords = maxRecords
+		a.maxBufferBytes = maxBytes
+		a.bufferFullPolicy = policy
+	}
+}
+
+// AppenderErrorHandler sets the function that is called when flushing records fails,
+// including the flushes of the background goroutine.
+// The handler is called from the goroutine that flushes, which may be the background goroutine
+// of AppenderFlushInterval. It is called without the lock of the buffer,
+// so it may call Append, Flush or Stats of the Appender, but it should not block long.
+func AppenderErrorHandler(fn func(err error)) AppenderOption {
+	return func(a *Appender) {
+		a.errorHandler = fn
+	}
+}
+
+// AppenderFlushResults sets the channel that receives the result of every flush.
+// The results are dropped if the channel is not ready to receive.
+func AppenderFlushResults(ch chan<- FlushResult) AppenderOption {
+	return func(a *Appender) {
+		a.flushResults = ch
+	}
+}
+
+// AppenderSpool enables the on-disk spool of the Appender.
+// When the append stream fails, the records that cannot be sent are persisted to the spool
+// and replayed in order once a new append stream is established,
+// including by a new Appender of the same table and the same spool directory.
+//
+// The Append protocol has no acknowledgement of each batch, so the records
+// that were passed to the stream just before it broke can not be recovered.
+// Replayed records may be delivered more than once.
+func AppenderSpool(cfg SpoolConfig) AppenderOption {
+	return func(a *Appender) {
+		a.spoolConfig = &cfg
+	}
+}
+
+// FlushResult is the result of a flush of the Appender.
+type FlushResult struct {
+	Records int           // number of records that were tried to send
+	Elapsed time.Duration // time spent to send
+	Err     error         // nil if the records were sent
+}
+
+type Appender struct {
+	ctx          context.Context
+	conn         *Conn
+	client       *Client
+	cli          MachbaseClient        // the endpoint of the appender
+	appendClient Machbase_AppendClient // guarded by bufferLock
+	streamMu     sync.Mutex
+	streamCancel context.CancelFunc // aborts the append stream, guarded by streamMu
+	streamErr    error              // non-nil if the append stream is broken
+	tableName    string             // set by the first open, immutable after
+	tableType    TableType          // set by the first open, immutable after
+	handle       *AppenderHandle
+	cols         *appenderColumns // replaced by open, guarded by bufferLock
+	structMaps   sync.Map         // map[reflect.Type][]*structField, fields in the order of columns
+	timeformat   string
+	timeLocation *time.Location
+	timeConv     *timeConverter
+
+	buffer      []*AppendRecord
+	bufferBytes int64
+	bufferLock  sync.Mutex
+	bufferFreed chan struct{} // closed and replaced to wake up the callers blocked by the full buffer
+
+	pendingResults []FlushResult // results of the flushes that are not delivered yet, guarded by bufferLock
+
+	bufferThreshold  int
+	flushBytes       int64
+	flushInterval    time.Duration
+	closeCh          chan struct{} // closed by Close to stop the worker
+	workerDone       chan struct{} // closed when the worker exits
+	closeOnce        sync.Once
+	maxBufferRecords int
+	maxBufferBytes   int64
+	bufferFullPolicy BufferFullPolicy
+	stats            appenderCounters
+
+	errorHandler func(error)
+	flushResults chan<- FlushResult
+	err          error // the first fatal error of the append stream
+	errLock      sync.Mutex
+
+	spoolConfig *SpoolConfig
+	spoolSubdir string // sub-directory of the spool of the table, set by ParallelAppender
+	spool       *spool
+}
+
+// Close releases all resources that allocated to the Appender
+func (appender *Appender) Close() (int64, int64, error) {
+	var success, fail int64
+	var err error
+	appender.closeOnce.Do(func() {
+		appender.client.untrack(appender)
+		success, fail, err = appender.close()
+	})
+	return success, fail, err
+}
+
+func (appender *Appender) close() (int64, int64, error) {
+	if appender.appendable() == sql.ErrTxDone {
+		return 0, 0, nil
+	}
+	defer appender.abortStream()
+
+	close(appender.closeCh)
+	<-appender.workerDone
+	appender.flush(nil)
+
+	appender.bufferLock.Lock()
+	client := appender.appendClient
+	appender.appendClient = nil
+	// wake up the callers that are blocked by the full buffer
+	appender.notifyBufferFreed()
+	appender.unlockBuffer()
+
+	if appender.spool != nil {
+		appender.spool.Close()
+	}
+	if err := appender.Err(); err != nil {
+		// the stream is already terminated
+		return 0, 0, err
+	}
+	if appender.streamErr != nil {
+		// the records are kept in the spool
+		return 0, 0, appender.streamErr
+	}
+	done, err := client.CloseAndRecv()
+	if done != nil {
+		appender.stats.serverSuccess.Store(done.SuccessCount)
+		appender.stats.serverFail.Store(done.FailCount)
+		return done.SuccessCount, done.FailCount, err
+	} else {
+		return 0, 0, err
+	}
+}
+
+func (appender *Appender) TableName() string {
+	return appender.tableName
+}
+
+func (appender *Appender) TableType() TableType {
+	return appender.tableType
+}
+
+// AppendWithTimestamp appends a new record with the given timestamp as the first value,
+// it is for the log table that takes the arrival time of the record.
+func (appender *Appender) AppendWithTimestamp(ts time.Time, cols ...any) error {
+	if err := appender.checkArity(appender.tableColumns(), len(cols)); err != nil {
+		return err
+	}
+	return appender.appendRecord(appender.ctx, append([]any{ts}, cols...), false)
+}
+
+// Flush sends the buffered records synchronously.
+//
+// If the ctx is done while sending, the append stream is aborted and Flush returns the error of the ctx.
+// The records are kept in the spool if it is enabled, otherwise the Appender is broken like any other
+// failure of the stream and the records that were not sent are lost.
+func (appender *Appender) Flush(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	appender.bufferLock.Lock()
+	defer appender.unlockBuffer()
+	if appender.appendClient == nil {
+		return sql.ErrTxDone
+	}
+	if err := appender.Err(); err != nil {
+		return err
+	}
+	if len(appender.buffer) == 0 && (appender.spool == nil || appender.spool.Empty()) {
+		return nil
+	}
+	stop := context.AfterFunc(ctx, appender.abortStream)
+	err := appender.sendBuffer()
+	if !stop() && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+func (appender *Appender) setStreamCancel(cancel context.CancelFunc) {
+	appender.streamMu.Lock()
+	defer appender.streamMu.Unlock()
+	if appender.streamCancel != nil {
+		// release the previous stream that was replaced by reopen
+		appender.streamCancel()
+	}
+	appender.streamCancel = cancel
+}
+
+// abortStream cancels the current append stream, the pending Send returns with the error.
+// It does not take bufferLock, so that it can be called while a flush holds it.
+func (appender *Appender) abortStream() {
+	appender.streamMu.Lock()
+	defer appender.streamMu.Unlock()
+	if appender.streamCancel != nil {
+		appender.streamCancel()
+	}
+}
+
+// Dropped returns the number of records that were discarded
+// by BufferFullDropOldest or BufferFullDropNewest policy.
+func (appender *Appender) Dropped() int64 {
+	return appender.stats.dropped.Load()
+}
+
+// AppenderStats is the statistics of an Appender.
+type AppenderStats struct {
+	Appended         int64         // number of records accepted by Append
+	Sent             int64         // number of records sent to the append stream
+	Batches          int64         // number of batches sent to the append stream
+	BytesSent        int64         // encoded size of the batches sent to the append stream
+	Buffered         int64         // number of records in the buffer
+	Dropped          int64         // number of records discarded by the BufferFullPolicy
+	Spooled          int64         // number of records written to the spool
+	SendErrors       int64         // number of flushes that failed
+	LastFlushLatency time.Duration // time spent by the last successful flush
+	ServerSuccess    int64         // number of records the server appended, reported on Close
+	ServerFail       int64         // number of records the server failed to append, reported on Close
+}
+
+type appenderCounters struct {
+	appended         atomic.Int64
+	sent             atomic.Int64
+	batches          atomic.Int64
+	bytesSent        atomic.Int64
+	buffered         atomic.Int64
+	dropped          atomic.Int64
+	spooled          atomic.Int64
+	sendErrors       atomic.Int64
+	lastFlushLatency atomic.Int64
+	serverSuccess    atomic.Int64
+	serverFail       atomic.Int64
+}
+
+// Stats returns the statistics of the Appender, it is safe to call from other goroutines.
+func (appender *Appender) Stats() AppenderStats {
+	c := &appender.stats
+	return AppenderStats{
+		Appended:         c.appended.Load(),
+		Sent:             c.sent.Load(),
+		Batches:          c.batches.Load(),
+		BytesSent:        c.bytesSent.Load(),
+		Buffered:         c.buffered.Load(),
+		Dropped:          c.dropped.Load(),
+		Spooled:          c.spooled.Load(),
+		SendErrors:       c.sendErrors.Load(),
+		LastFlushLatency: time.Duration(c.lastFlushLatency.Load()),
+		ServerSuccess:    c.serverSuccess.Load(),
+		ServerFail:       c.serverFail.Load(),
+	}
+}
+
+// Err returns the first fatal error of the append stream.
+// Once the stream fails, Append returns the error and buffered records are not sent.
+func (appender *Appender) Err() error {
+	appender.errLock.Lock()
+	defer appender.errLock.Unlock()
+	return appender.err
+}
+
+func (appender *Appender) setErr(err error) {
+	appender.errLock.Lock()
+	defer appender.errLock.Unlock()
+	if appender.err == nil {
+		appender.err = err
+	}
+}
+
+// Append appends a new record of the table.
+func (appender *Appender) Append(cols ...any) error {
+	return appender.AppendContext(appender.ctx, cols...)
+}
+
+// AppendContext appends a new record of the table.
+// The ctx bounds the time Append is blocked by the full buffer with BufferFullBlock policy.
+func (appender *Appender) AppendContext(ctx context.Context, cols ...any) error {
+	return appender.appendRecord(ctx, cols, true)
+}
+
+// appendRecord appends a new record, byColumns is true if cols are in the order of the columns of the table.
+func (appender *Appender) appendRecord(ctx context.Context, cols []any, byColumns bool) error {
+	if err := appender.appendable(); err != nil {
+		return err
+	}
+
+	ac := appender.tableColumns()
+	if byColumns {
+		if err := appender.checkArity(ac, len(cols)); err != nil {
+			return err
+		}
+	}
+
+	names := ac.names
+	if !byColumns {
+		// the values are shifted by the timestamp
+		names = nil
+	}
+	params, err := convertTuple(cols, names)
+	if err != nil {
+		return err
+	}
+	if byColumns {
+		if err := appender.convertTimes(ac, params); err != nil {
+			return err
+		}
+	}
+	err = appender.flushContext(ctx, &AppendRecord{Tuple: params})
+	return err
+}
+
+// appendable returns the error if the Appender can not take a new record.
+func (appender *Appender) appendable() error {
+	appender.bufferLock.Lock()
+	closed := appender.appendClient == nil
+	appender.bufferLock.Unlock()
+	if closed {
+		return sql.ErrTxDone
+	}
+	if err := appender.Err(); err != nil {
+		return err
+	}
+	return appender.ctx.Err()
+}
+
+// Columns returns the names and types of the columns of the table in the order of Append.
+// If the server does not report the columns when the Appender opens, they are queried from the table.
+func (appender *Appender) Columns() ([]string, []string, error) {
+	ac := appender.tableColumns()
+	if ac.columns == nil {
+		return nil, nil, errors.New("server does not provide the columns of the appender")
+	}
+	names := make([]string, len(ac.columns))
+	types := make([]string, len(ac.columns))
+	for i, c := range ac.columns {
+		names[i] = c.Name
+		types[i] = c.Type
+	}
+	return names, types, nil
+}
+
+// checkArity returns error if the number of values does not match the columns of the table.
+func (appender *Appender) checkArity(ac *appenderColumns, n int) error {
+	if ac.columns != nil && n != len(ac.columns) {
+		return fmt.Errorf("%d values, but table %s has %d columns", n, appender.tableName, len(ac.columns))
+	}
+	return nil
+}
+
+// appenderColumns are the columns of the table that the server reported when the appender was opened,
+// it is not modified once created.
+type appenderColumns struct {
+	columns     []*Column // nil if the server does not report the columns
+	names       []string
+	timeColumns []int // indexes of the datetime columns
+}
+
+func newAppenderColumns(columns []*Column) *appenderColumns {
+	ac := &appenderColumns{columns: columns}
+	for i, c := range columns {
+		ac.names = append(ac.names, c.Name)
+		if typ, err := ParseColumnType(c.Type); err == nil && typ == DatetimeColumnType {
+			ac.timeColumns = append(ac.timeColumns, i)
+		}
+	}
+	return ac
+}
+
+// tableColumns returns the current columns of the table, open may replace them on reopen.
+func (appender *Appender) tableColumns() *appenderColumns {
+	appender.bufferLock.Lock()
+	defer appender.bufferLock.Unlock()
+	return appender.cols
+}
+
+// force flush if rec is nil
+// allow buffering if rec is not nil
+func (appender *Appender) flush(rec *AppendRecord) error {
+	return appender.flushContext(appender.ctx, rec)
+}
+
+func (appender *Appender) flushContext(ctx context.Context, rec *AppendRecord) error {
+	_, err := appender.bufferRecord(ctx, rec)
+	return err
+}
+
+// bufferRecord buffers the rec and sends the buffer if it reaches the thresholds, it forces flush if rec is nil.
+// It returns false if the rec is not buffered, including the rec dropped by BufferFullDropNewest.
+func (appender *Appender) bufferRecord(ctx context.Context, rec *AppendRecord) (bool, error) {
+	appender.bufferLock.Lock()
+	defer appender.unlockBuffer()
+	if appender.appendClient == nil {
+		// closed after the caller checked appendable
+		return false, sql.ErrTxDone
+	}
+
+	if rec != nil {
+		size := int64(0)
+		if appender.trackBytes() {
+			size = int64(proto.Size(rec))
+		}
+		if ok, err := appender.makeRoom(ctx, size); !ok {
+			return false, err
+		}
+		appender.buffer = append(appender.buffer, rec)
+		appender.bufferBytes += size
+		appender.stats.appended.Add(1)
+		appender.stats.buffered.Store(int64(len(appender.buffer)))
+	}
+	buffered := rec != nil
+	if len(appender.buffer) == 0 {
+		return buffered, nil
+	}
+
+	if rec != nil && len(appender.buffer) < appender.bufferThreshold &&
+		(appender.flushBytes <= 0 || appender.bufferBytes < appender.flushBytes) {
+		// write new record, but not enough to flush to network
+		return buffered, nil
+	}
+
+	if err := appender.Err(); err != nil {
+		return buffered, err
+	}
+	err := appender.sendBuffer()
+	if err == ErrSpoolFull && rec != nil && appender.bufferLimited() {
+		// the record is kept in the buffer, the buffer limit takes over
+		return buffered, nil
+	}
+	return buffered, err
+}
+
+// trackBytes returns true if the encoded size of the buffered records is needed.
+func (appender *Appender) trackBytes() bool {
+	return appender.maxBufferBytes > 0 || appender.flushBytes > 0
+}
+
+func (appender *Appender) bufferLimited() bool {
+	return appender.maxBufferRecords > 0 || appender.maxBufferBytes > 0
+}
+
+// sendBuffer sends the buffered records, the caller should hold bufferLock.
+// If the spool is enabled, it re-establishes the broken stream and replays the spooled records first.
+func (appender *Appender) sendBuffer() error {
+	if appender.spool != nil {
+		if appender.streamErr != nil {
+			if err := appender.open(); err != nil {
+				appender.report(FlushResult{Records: len(appender.buffer), Err: err})
+				return appender.spoolBuffer()
+			}
+		}
+		if !appender.spool.Empty() {
+			if err := appender.spool.Replay(appender.bufferThreshold, appender.send); err != nil {
+				appender.streamErr = err
+				return appender.spoolBuffer()
+			}
+		}
+	}
+	if len(appender.buffer) == 0 {
+		return nil
+	}
+	if err := appender.send(appender.buffer); err != nil {
+		if appender.spool != nil {
+			appender.streamErr = err
+			return appender.spoolBuffer()
+		}
+		appender.setErr(errors.Wrap(wrapError("Append", err), "append stream"))
+		appender.notifyBufferFreed()
+		return appender.Err()
+	}
+	appender.clearBuffer()
+	return nil
+}
+
+// bufferFull returns true if a new record of the size exceeds the limit of the buffer.
+// A record is always accepted by the empty buffer.
+func (appender *Appender) bufferFull(size int64) bool {
+	if len(appender.buffer) == 0 {
+		return false
+	}
+	if appender.maxBufferRecords > 0 && len(appender.buffer) >= appender.maxBufferRecords {
+		return true
+	}
+	if appender.maxBufferBytes > 0 && appender.bufferBytes+size > appender.maxBufferBytes {
+		return true
+	}
+	return false
+}
+
+// makeRoom makes room for a new record of the size in the buffer according to the BufferFullPolicy.
+// It returns false if the record should not be buffered, with the error for the caller.
+// The caller should hold bufferLock, it is released while blocked.
+func (appender *Appender) makeRoom(ctx context.Context, size int64) (bool, error) {
+	for appender.bufferFull(size) {
+		if err := appender.Err(); err != nil {
+			return false, err
+		}
+		if appender.appendClient == nil {
+			return false, sql.ErrTxDone
+		}
+		// try to send the buffered records first
+		if err := appender.sendBuffer(); err != nil && err != ErrSpoolFull {
+			return false, err
+		}
+		if !appender.bufferFull(size) {
+			break
+		}
+		switch appender.bufferFullPolicy {
+		case BufferFullDropNewest:
+			appender.stats.dropped.Add(1)
+			return false, nil
+		case BufferFullDropOldest:
+			for appender.bufferFull(size) {
+				if appender.trackBytes() {
+					appender.bufferBytes -= int64(proto.Size(appender.buffer[0]))
+				}
+				appender.buffer[0] = nil
+				appender.buffer = appender.buffer[1:]
+				appender.stats.dropped.Add(1)
+			}
+			appender.stats.buffered.Store(int64(len(appender.buffer)))
+			return true, nil
+		case BufferFullBlock:
+			freed := appender.bufferFreed
+			appender.unlockBuffer()
+			select {
+			case <-ctx.Done():
+				appender.bufferLock.Lock()
+				return false, ctx.Err()
+			case <-freed:
+			}
+			appender.bufferLock.Lock()
+		default:
+			return false, ErrBufferFull
+		}
+	}
+	return true, nil
+}
+
+// clearBuffer empties the buffer and wakes up the blocked callers, the caller should hold bufferLock.
+func (appender *Appender) clearBuffer() {
+	appender.buffer = appender.buffer[:0]
+	appender.bufferBytes = 0
+	appender.stats.buffered.Store(0)
+	appender.notifyBufferFreed()
+}
+
+func (appender *Appender) notifyBufferFreed() {
+	close(appender.bufferFreed)
+	appender.bufferFreed = make(chan struct{})
+}
+
+// send sends the records to the append stream and reports the result, the caller should hold bufferLock.
+func (appender *Appender) send(recs []*AppendRecord) error {
+	if appender.appendClient == nil {
+		return sql.ErrTxDone
+	}
+	tick := time.Now()
+	data := &AppendData{
+		Handle:  appender.handle,
+		Records: recs,
+	}
+	err := appender.appendClient.Send(data)
+	if err == io.EOF {
+		// the stream was aborted, the actual error is reported by CloseAndRecv
+		if _, recvErr := appender.appendClient.CloseAndRecv(); recvErr != nil {
+			err = recvErr
+		}
+	}
+	elapsed := time.Since(tick)
+	if err == nil {
+		appender.stats.sent.Add(int64(len(recs)))
+		appender.stats.batches.Add(1)
+		appender.stats.bytesSent.Add(int64(proto.Size(data)))
+		appender.stats.lastFlushLatency.Store(int64(elapsed))
+	}
+	appender.report(FlushResult{Records: len(recs), Elapsed: elapsed, Err: err})
+	return err
+}
+
+// spoolBuffer persists the buffered records into the spool, the caller should hold bufferLock.
+func (appender *Appender) spoolBuffer() error {
+	if len(appender.buffer) == 0 {
+		return nil
+	}
+	if err := appender.spool.Write(appender.buffer); err != nil {
+		if err == ErrSpoolFull {
+			// keep the records in the buffer
+			return err
+		}
+		appender.setErr(err)
+		appender.notifyBufferFreed()
+		return err
+	}
+	appender.stats.spooled.Add(int64(len(appender.buffer)))
+	appender.clearBuffer()
+	return nil
+}
+
+// report records the result of a flush, the caller should hold bufferLock.
+// The result is delivered to the handlers by unlockBuffer.
+func (appender *Appender) report(result FlushResult) {
+	if result.Err != nil {
+		appender.stats.sendErrors.Add(1)
+	}
+	appender.pendingResults = append(appender.pendingResults, result)
+}
+
+// unlockBuffer releases bufferLock, then delivers the results of the flushes
+// to the error handler, the result channel and the hooks of the client,
+// so that they can call the Appender.
+func (appender *Appender) unlockBuffer() {
+	results := appender.pendingResults
+	appender.pendingResults = nil
+	appender.bufferLock.Unlock()
+	for _, result := range results {
+		if result.Err != nil && appender.errorHandler != nil {
+			appender.errorHandler(result.Err)
+		}
+		appender.client.traceFlush(appender.ctx, appender.tableName, result)
+		if appender.flushResults != nil {
+			select {
+			case appender.flushResults <- result:
+			default:
+			}
+		}
+	}
+}
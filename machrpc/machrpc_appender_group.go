@@ -0,0 +1,326 @@
+package machrpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrAppenderGroupClosed is returned by AppenderGroup.Append after the group is closed.
+var ErrAppenderGroupClosed = errors.New("appender group is closed")
+
+// AppenderGroup routes records to the appenders of multiple tables over a connection.
+// The appender of a table is opened by the first Append to the table,
+// and the buffers of all appenders are flushed by a single background goroutine.
+//
+//	group := conn.AppenderGroup(ctx, machrpc.GroupIdleTimeout(time.Minute))
+//	group.Append("tag_a", "name", time.Now(), 1.0)
+//	group.Append("tag_b", "name", time.Now(), 2.0)
+//	counts, err := group.Close()
+type AppenderGroup struct {
+	ctx  context.Context
+	conn *Conn
+
+	appenderOpts  []AppenderOption
+	flushInterval time.Duration
+	idleTimeout   time.Duration
+
+	mu        sync.RWMutex
+	appenders map[string]*groupEntry // key is upper-case table name
+	counts    map[string]*AppendCounts
+	errs      []error
+	closed    bool
+
+	closeCh    chan struct{}
+	workerDone chan struct{}
+}
+
+type groupEntry struct {
+	appender *Appender
+	lastUsed atomic.Int64 // unix nano of the last Append
+	refs     int          // number of the calls that use the appender, guarded by the mu of the group
+}
+
+// AppendCounts is the number of records that the server appended and failed to append.
+type AppendCounts struct {
+	Success int64
+	Fail    int64
+}
+
+type AppenderGroupOption func(*AppenderGroup)
+
+// GroupAppenderOptions sets the options of the appenders of the group.
+// AppenderFlushInterval is ignored, the group flushes the appenders by GroupFlushInterval.
+func GroupAppenderOptions(opts ...AppenderOption) AppenderGroupOption {
+	return func(g *AppenderGroup) {
+		g.appenderOpts = append(g.appenderOpts, opts...)
+	}
+}
+
+// GroupFlushInterval sets the interval of the background flush of all appenders (default 1s).
+// If d <= 0, the buffers are flushed only by the thresholds of the appenders, Flush and Close.
+func GroupFlushInterval(d time.Duration) AppenderGroupOption {
+	return func(g *AppenderGroup) {
+		g.flushInterval = d
+	}
+}
+
+// GroupIdleTimeout closes the appender of a table that has no Append for the duration d.
+// The appender is opened again by the next Append to the table. If d <= 0, appenders are kept open (default).
+func GroupIdleTimeout(d time.Duration) AppenderGroupOption {
+	return func(g *AppenderGroup) {
+		g.idleTimeout = d
+	}
+}
+
+// AppenderGroup creates a new AppenderGroup that opens appenders over the conn.
+// AppenderGroup should be closed to close all appenders of the group.
+func (conn *Conn) AppenderGroup(ctx context.Context, opts ...AppenderGroupOption) *AppenderGroup {
+	g := &AppenderGroup{
+		ctx:           ctx,
+		conn:          conn,
+		flushInterval: time.Second,
+		appenders:     map[string]*groupEntry{},
+		counts:        map[string]*AppendCounts{},
+		closeCh:       make(chan struct{}),
+		workerDone:    make(chan struct{}),
+	}
+	for _, o := range opts {
+		o(g)
+	}
+	if interval := g.workerInterval(); interval > 0 {
+		go g.worker(interval)
+	} else {
+		close(g.workerDone)
+	}
+	return g
+}
+
+// Append appends a new record to the table, it opens the appender of the table if necessary.
+func (g *AppenderGroup) Append(table string, cols ...any) error {
+	key := strings.ToUpper(table)
+	for {
+		ent, err := g.acquire(key)
+		if err != nil {
+			return err
+		}
+		if ent != nil {
+			// the reference prevents the appender from being closed by the idle timeout,
+			// the lock of the group is not held while Append may block
+			err := ent.appender.Append(cols...)
+			g.release(ent)
+			return err
+		}
+		if err := g.open(key, table); err != nil {
+			return err
+		}
+	}
+}
+
+// acquire returns the entry of the table with a reference, nil if the appender of the table is not opened.
+func (g *AppenderGroup) acquire(key string) (*groupEntry, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return nil, ErrAppenderGroupClosed
+	}
+	ent, ok := g.appenders[key]
+	if !ok {
+		return nil, nil
+	}
+	ent.refs++
+	ent.lastUsed.Store(time.Now().UnixNano())
+	return ent, nil
+}
+
+// acquireAll returns all entries of the group with a reference.
+func (g *AppenderGroup) acquireAll() []*groupEntry {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ret := make([]*groupEntry, 0, len(g.appenders))
+	for _, ent := range g.appenders {
+		ent.refs++
+		ret = append(ret, ent)
+	}
+	return ret
+}
+
+func (g *AppenderGroup) release(ents ...*groupEntry) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, ent := range ents {
+		ent.refs--
+	}
+}
+
+// open opens the appender of the table if it is not opened yet.
+// The appender is opened without the lock not to block the other tables,
+// it is closed if another call opened the table or the group was closed meanwhile.
+func (g *AppenderGroup) open(key string, table string) error {
+	g.mu.RLock()
+	closed := g.closed
+	_, ok := g.appenders[key]
+	g.mu.RUnlock()
+	if closed {
+		return ErrAppenderGroupClosed
+	}
+	if ok {
+		return nil
+	}
+	opts := append(append([]AppenderOption{}, g.appenderOpts...), AppenderFlushInterval(0))
+	appender, err := g.conn.Appender(g.ctx, table, opts...)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	closed = g.closed
+	_, ok = g.appenders[key]
+	if !closed && !ok {
+		ent := &groupEntry{appender: appender}
+		ent.lastUsed.Store(time.Now().UnixNano())
+		g.appenders[key] = ent
+	}
+	g.mu.Unlock()
+	if closed || ok {
+		appender.Close()
+	}
+	if closed {
+		return ErrAppenderGroupClosed
+	}
+	return nil
+}
+
+// Tables returns the names of the tables that have an open appender.
+func (g *AppenderGroup) Tables() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	ret := make([]string, 0, len(g.appenders))
+	for _, ent := range g.appenders {
+		ret = append(ret, ent.appender.TableName())
+	}
+	return ret
+}
+
+// Flush sends the buffered records of all appenders synchronously.
+func (g *AppenderGroup) Flush(ctx context.Context) error {
+	ents := g.acquireAll()
+	defer g.release(ents...)
+	var errs []error
+	for _, ent := range ents {
+		if err := ent.appender.Flush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ent.appender.TableName(), err))
+		}
+	}
+	return joinErrors(errs...)
+}
+
+// Close closes all appenders of the group and returns the counts of appended records by table name,
+// including the appenders that were closed by the idle timeout.
+// The Append calls in progress may fail with sql.ErrTxDone as they do on a closed Appender,
+// or with ErrAppenderGroupClosed if they are opening the appender of the table.
+func (g *AppenderGroup) Close() (map[string]AppendCounts, error) {
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return nil, nil
+	}
+	g.closed = true
+	close(g.closeCh)
+	g.mu.Unlock()
+
+	<-g.workerDone
+
+	g.mu.Lock()
+	ents := make([]*groupEntry, 0, len(g.appenders))
+	for key, ent := range g.appenders {
+		delete(g.appenders, key)
+		ents = append(ents, ent)
+	}
+	g.mu.Unlock()
+	for _, ent := range ents {
+		g.closeEntry(ent)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ret := make(map[string]AppendCounts, len(g.counts))
+	for table, cnt := range g.counts {
+		ret[table] = *cnt
+	}
+	return ret, joinErrors(g.errs...)
+}
+
+// closeEntry closes the appender that was removed from the group and accumulates its counts.
+// It is called without the lock, so that the callbacks of the appender can call the group.
+func (g *AppenderGroup) closeEntry(ent *groupEntry) {
+	table := ent.appender.TableName()
+	succ, fail, err := ent.appender.Close()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	cnt, ok := g.counts[table]
+	if !ok {
+		cnt = &AppendCounts{}
+		g.counts[table] = cnt
+	}
+	cnt.Success += succ
+	cnt.Fail += fail
+	if err != nil {
+		g.errs = append(g.errs, fmt.Errorf("%s: %w", table, err))
+	}
+}
+
+func (g *AppenderGroup) workerInterval() time.Duration {
+	d := g.flushInterval
+	if d <= 0 || (g.idleTimeout > 0 && g.idleTimeout < d) {
+		d = g.idleTimeout
+	}
+	return d
+}
+
+// worker flushes all appenders and closes the idle appenders periodically.
+func (g *AppenderGroup) worker(interval time.Duration) {
+	defer close(g.workerDone)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastFlush := time.Now()
+	for {
+		select {
+		case <-g.closeCh:
+			return
+		case <-g.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		now := time.Now()
+		if g.flushInterval > 0 && now.Sub(lastFlush) >= g.flushInterval {
+			lastFlush = now
+			ents := g.acquireAll()
+			for _, ent := range ents {
+				// the errors are reported by the appender
+				ent.appender.flush(nil)
+			}
+			g.release(ents...)
+		}
+		if g.idleTimeout > 0 {
+			var idle []*groupEntry
+			g.mu.Lock()
+			for key, ent := range g.appenders {
+				// the appender in use is not idle
+				if ent.refs == 0 && now.Sub(time.Unix(0, ent.lastUsed.Load())) >= g.idleTimeout {
+					delete(g.appenders, key)
+					idle = append(idle, ent)
+				}
+			}
+			g.mu.Unlock()
+			for _, ent := range idle {
+				g.closeEntry(ent)
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package machrpc
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"time"
+)
+
+// AppendColumns appends len(names) records from the column-oriented slices,
+// the i-th record is (names[i], times[i], values[0][i], values[1][i], ...).
+// It is for the tag table, the most common form is (name, time, value).
+//
+// Each of values should be a slice of the same length as names.
+// []float64, []float32, []int64, []int32, []int, []uint64, []uint32, []string, []bool,
+// []time.Time and []net.IP are converted without per-record type switches,
+// other slices (e.g. []any) are converted element by element as Append does.
+//
+// It returns the number of records that were appended, which is less than len(names)
+// with the error if the Appender fails in the middle of the batch,
+// the records dropped by BufferFullDropNewest are not counted.
+//
+//	n, err := app.AppendColumns(
+//		[]string{"tag1", "tag2"},
+//		[]time.Time{ts1, ts2},
+//		[]float64{1.5, 2.5})
+func (appender *Appender) AppendColumns(names []string, times []time.Time, values ...any) (int, error) {
+	nrec := len(names)
+	if len(times) != nrec {
+		return 0, fmt.Errorf("%d names, but %d times", nrec, len(times))
+	}
+	ncol := 2 + len(values)
+	ac := appender.tableColumns()
+	if err := appender.checkArity(ac, ncol); err != nil {
+		return 0, err
+	}
+	if nrec == 0 {
+		return 0, nil
+	}
+
+	// allocate the records of the batch at once
+	records := make([]AppendRecord, nrec)
+	tuples := make([]*AppendDatum, nrec*ncol)
+	for i := range records {
+		records[i].Tuple = tuples[i*ncol : (i+1)*ncol : (i+1)*ncol]
+	}
+	if err := fillColumn(tuples, ncol, 0, names); err != nil {
+		return 0, columnError(ac, 0, err)
+	}
+	if err := fillColumn(tuples, ncol, 1, times); err != nil {
+		return 0, columnError(ac, 1, err)
+	}
+	for c, vals := range values {
+		if err := fillColumn(tuples, ncol, c+2, vals); err != nil {
+			return 0, columnError(ac, c+2, err)
+		}
+	}
+
+	for i := range records {
+		if err := appender.convertTimes(ac, records[i].Tuple); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := appender.appendable(); err != nil {
+		return 0, err
+	}
+	appended := 0
+	for i := range records {
+		ok, err := appender.bufferRecord(appender.ctx, &records[i])
+		if err != nil {
+			return appended, err
+		}
+		if ok {
+			appended++
+		}
+	}
+	return appended, nil
+}
+
+func columnError(ac *appenderColumns, idx int, err error) error {
+	if idx < len(ac.names) {
+		return fmt.Errorf("convert column %q: %w", ac.names[idx], err)
+	}
+	return fmt.Errorf("convert column[%d]: %w", idx, err)
+}
+
+// fillColumn sets the col-th datum of every record in tuples from the slice vals.
+func fillColumn(tuples []*AppendDatum, ncol int, col int, vals any) error {
+	switch vs := vals.(type) {
+	case []string:
+		return fillTyped(tuples, ncol, col, vs, func(o *AppendDatum_VString, v string) { o.VString = v })
+	case []time.Time:
+		for _, v := range vs {
+			if _, err := timeNanos(v); err != nil {
+				return err
+			}
+		}
+		return fillTyped(tuples, ncol, col, vs, func(o *AppendDatum_VTime, v time.Time) { o.VTime = v.UnixNano() })
+	case []float64:
+		return fillTyped(tuples, ncol, col, vs, func(o *AppendDatum_VDouble, v float64) { o.VDouble = v })
+	case []float32:
+		return fillTyped(tuples, ncol, col, vs, func(o *AppendDatum_VFloat, v float32) { o.VFloat = v })
+	case []int64:
+		return fillTyped(tuples, ncol, col, vs, func(o *AppendDatum_VInt64, v int64) { o.VInt64 = v })
+	case []int32:
+		return fillTyped(tuples, ncol, col, vs, func(o *AppendDatum_VInt32, v int32) { o.VInt32 = v })
+	case []int:
+		return fillTyped(tuples, ncol, col, vs, func(o *AppendDatum_VInt64, v int) { o.VInt64 = int64(v) })
+	case []uint64:
+		return fillTyped(tuples, ncol, col, vs, func(o *AppendDatum_VUint64, v uint64) { o.VUint64 = v })
+	case []uint32:
+		return fillTyped(tuples, ncol, col, vs, func(o *AppendDatum_VUint32, v uint32) { o.VUint32 = v })
+	case []bool:
+		return fillTyped(tuples, ncol, col, vs, func(o *AppendDatum_VBool, v bool) { o.VBool = v })
+	case []net.IP:
+		return fillTyped(tuples, ncol, col, vs, func(o *AppendDatum_VIp, v net.IP) { o.VIp = v.String() })
+	}
+
+	rv := reflect.ValueOf(vals)
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("column values should be slice, not %T", vals)
+	}
+	nrec := len(tuples) / ncol
+	if rv.Len() != nrec {
+		return lengthError(nrec, rv.Len())
+	}
+	for i := 0; i < nrec; i++ {
+		d, err := convertDatum(rv.Index(i).Interface(), 0)
+		if err != nil {
+			return fmt.Errorf("record[%d]: %w", i, err)
+		}
+		tuples[i*ncol+col] = d
+	}
+	return nil
+}
+
+// fillTyped sets the col-th datum of every record in tuples from vs,
+// the datums and their values are allocated at once.
+func fillTyped[T any, O any, PO interface {
+	*O
+	isAppendDatum_Value
+}](tuples []*AppendDatum, ncol int, col int, vs []T, set func(PO, T)) error {
+	nrec := len(tuples) / ncol
+	if len(vs) != nrec {
+		return lengthError(nrec, len(vs))
+	}
+	datums := make([]AppendDatum, nrec)
+	oneofs := make([]O, nrec)
+	for i, v := range vs {
+		set(PO(&oneofs[i]), v)
+		datums[i].Value = PO(&oneofs[i])
+		tuples[i*ncol+col] = &datums[i]
+	}
+	return nil
+}
+
+func lengthError(expect, actual int) error {
+	return fmt.Errorf("%d values, but %d records", actual, expect)
+}
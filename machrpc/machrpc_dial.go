@@ -0,0 +1,79 @@
+package machrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/encoding/gzip" // registers "gzip" for Config.AppendCompression
+	"google.golang.org/grpc/metadata"
+)
+
+// DefaultMetadata is the metadata that the client sends with every call if Config.Metadata is nil.
+var DefaultMetadata = map[string]string{"client": "machrpc"}
+
+// dialOptions returns the gRPC dial options of the config.
+func (cfg *Config) dialOptions() []grpc.DialOption {
+	opts := []grpc.DialOption{}
+	if cfg.Keepalive != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(*cfg.Keepalive))
+	}
+	callOpts := []grpc.CallOption{}
+	if cfg.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(cfg.MaxRecvMsgSize))
+	}
+	if cfg.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(cfg.MaxSendMsgSize))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	if cfg.UserAgent != "" {
+		opts = append(opts, grpc.WithUserAgent(cfg.UserAgent))
+	}
+
+	md := cfg.Metadata
+	if md == nil {
+		md = DefaultMetadata
+	}
+	unary := cfg.UnaryInterceptors
+	stream := cfg.StreamInterceptors
+	if len(md) > 0 {
+		pairs := make([]string, 0, len(md)*2)
+		for k, v := range md {
+			pairs = append(pairs, k, v)
+		}
+		unary = append([]grpc.UnaryClientInterceptor{unaryMetadataInterceptor(pairs)}, unary...)
+		stream = append([]grpc.StreamClientInterceptor{streamMetadataInterceptor(pairs)}, stream...)
+	}
+	if len(unary) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(unary...))
+	}
+	if len(stream) > 0 {
+		opts = append(opts, grpc.WithChainStreamInterceptor(stream...))
+	}
+	return append(opts, cfg.DialOptions...)
+}
+
+// withMetadata adds the key-value pairs to the outgoing metadata of ctx,
+// the keys that ctx already has are not overwritten.
+func withMetadata(ctx context.Context, pairs []string) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for i := 0; i < len(pairs); i += 2 {
+		if len(md.Get(pairs[i])) == 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, pairs[i], pairs[i+1])
+		}
+	}
+	return ctx
+}
+
+func unaryMetadataInterceptor(pairs []string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withMetadata(ctx, pairs), method, req, reply, cc, opts...)
+	}
+}
+
+func streamMetadataInterceptor(pairs []string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withMetadata(ctx, pairs), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,194 @@
+package machrpc
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EndpointPolicy selects the endpoint of a new session when the Client has multiple endpoints.
+// The sessions are bound to the endpoint that made them, a session moves to other endpoint
+// only when it is re-established because its endpoint is not available.
+type EndpointPolicy int
+
+const (
+	// EndpointFailover selects the first healthy endpoint in the order of the endpoints.
+	// The sessions that are made after the preferred endpoint recovered go back to it.
+	EndpointFailover EndpointPolicy = iota
+	// EndpointRoundRobin distributes the sessions over the healthy endpoints in turn.
+	EndpointRoundRobin
+	// EndpointPickFirst stays on the selected endpoint until it fails,
+	// then it selects the next healthy endpoint.
+	EndpointPickFirst
+)
+
+// DefaultHealthCheckInterval is the default interval of probing the endpoints.
+const DefaultHealthCheckInterval = 5 * time.Second
+
+// EndpointStatus is the status of an endpoint of the Client.
+type EndpointStatus struct {
+	Addr      string
+	Healthy   bool
+	LastError error // the reason of the unhealthy state, nil if it is healthy
+	Conns     int   // number of the connections that use the endpoint
+	Active    bool  // true if the new connections are made to the endpoint
+}
+
+type endpoint struct {
+	addr string
+	conn grpc.ClientConnInterface
+	cli  MachbaseClient
+
+	logger  *slog.Logger // logs the changes of the health, nil disables logging
+	healthy atomic.Bool
+	conns   atomic.Int32
+	errLock sync.Mutex
+	lastErr error
+}
+
+func newEndpoint(addr string, conn grpc.ClientConnInterface, logger *slog.Logger) *endpoint {
+	ep := &endpoint{addr: addr, conn: conn, cli: NewMachbaseClient(conn), logger: logger}
+	ep.healthy.Store(true)
+	return ep
+}
+
+// setHealth marks the endpoint healthy if err is nil, unhealthy otherwise.
+func (ep *endpoint) setHealth(err error) {
+	ep.errLock.Lock()
+	ep.lastErr = err
+	ep.errLock.Unlock()
+	if was := ep.healthy.Swap(err == nil); was != (err == nil) && ep.logger != nil {
+		if err != nil {
+			ep.logger.Warn("machrpc endpoint is unavailable", "endpoint", ep.addr, "error", err.Error())
+		} else {
+			ep.logger.Info("machrpc endpoint is recovered", "endpoint", ep.addr)
+		}
+	}
+}
+
+func (ep *endpoint) status() EndpointStatus {
+	ep.errLock.Lock()
+	defer ep.errLock.Unlock()
+	return EndpointStatus{Addr: ep.addr, Healthy: ep.healthy.Load(), LastError: ep.lastErr, Conns: int(ep.conns.Load())}
+}
+
+// isUnavailable returns true if err means the endpoint is not reachable.
+func isUnavailable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+// candidates returns the endpoints in the order of preference of the policy,
+// the healthy endpoints come first and the unhealthy endpoints are the last resort.
+func (client *Client) candidates() []*endpoint {
+	n := len(client.endpoints)
+	if n == 1 {
+		return client.endpoints
+	}
+	start := 0
+	switch client.endpointPolicy {
+	case EndpointRoundRobin:
+		start = int((client.nextEndpoint.Add(1) - 1) % uint32(n))
+	case EndpointPickFirst:
+		start = int(client.nextEndpoint.Load() % uint32(n))
+	}
+	ret := make([]*endpoint, 0, n)
+	var unhealthy []*endpoint
+	for i := 0; i < n; i++ {
+		ep := client.endpoints[(start+i)%n]
+		if ep.healthy.Load() {
+			ret = append(ret, ep)
+		} else {
+			unhealthy = append(unhealthy, ep)
+		}
+	}
+	return append(ret, unhealthy...)
+}
+
+// activeEndpoint returns the endpoint that the client prefers now.
+func (client *Client) activeEndpoint() *endpoint {
+	if client.endpointPolicy == EndpointRoundRobin {
+		// do not advance the turn
+		n := len(client.endpoints)
+		start := int(client.nextEndpoint.Load() % uint32(n))
+		for i := 0; i < n; i++ {
+			if ep := client.endpoints[(start+i)%n]; ep.healthy.Load() {
+				return ep
+			}
+		}
+		return client.endpoints[start]
+	}
+	return client.candidates()[0]
+}
+
+// ActiveEndpoint returns the address of the endpoint that the new connection is made to.
+func (client *Client) ActiveEndpoint() string {
+	return client.activeEndpoint().addr
+}
+
+// Endpoints returns the status of the endpoints in the order of the configuration.
+func (client *Client) Endpoints() []EndpointStatus {
+	active := client.activeEndpoint()
+	ret := make([]EndpointStatus, len(client.endpoints))
+	for i, ep := range client.endpoints {
+		ret[i] = ep.status()
+		ret[i].Active = ep == active
+	}
+	return ret
+}
+
+// connect makes a session on the first reachable endpoint of the candidates.
+func (client *Client) connect(ctx context.Context, req *ConnRequest) (*endpoint, *ConnResponse, error) {
+	var err error
+	for _, ep := range client.candidates() {
+		var rsp *ConnResponse
+		rsp, err = ep.cli.Conn(ctx, req)
+		if err != nil {
+			if isUnavailable(err) && ctx.Err() == nil {
+				ep.setHealth(err)
+				continue
+			}
+			return nil, nil, err
+		}
+		ep.setHealth(nil)
+		if client.endpointPolicy == EndpointPickFirst {
+			for i := range client.endpoints {
+				if client.endpoints[i] == ep {
+					client.nextEndpoint.Store(uint32(i))
+				}
+			}
+		}
+		return ep, rsp, nil
+	}
+	return nil, nil, err
+}
+
+// healthCheck probes the endpoints every interval until the client is closed.
+func (client *Client) healthCheck(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-client.closeCh:
+			return
+		case <-ticker.C:
+			for _, ep := range client.endpoints {
+				client.probe(ep, interval)
+			}
+		}
+	}
+}
+
+// probe checks the endpoint with GetServerInfo.
+func (client *Client) probe(ep *endpoint, timeout time.Duration) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), timeout)
+	defer cancelFunc()
+	_, err := ep.cli.GetServerInfo(ctx, &ServerInfoRequest{})
+	// the server that responds is healthy even if it reports a failure
+	ep.setHealth(err)
+}
@@ -0,0 +1,117 @@
+package machrpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/machbase/neo-client/machrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEndpointFailover(t *testing.T) {
+	// the session on the stopped endpoint is abandoned
+	defer mockServer.ResetSessions()
+	svrA, addrA := listenServer(t, "127.0.0.1:0")
+	_, addrB := listenServer(t, "127.0.0.1:0")
+
+	cli, err := machrpc.NewClient(&machrpc.Config{
+		ServerAddr:          addrA,
+		Endpoints:           []string{addrB},
+		HealthCheckInterval: -1,
+		Retry:               &machrpc.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+	})
+	require.Nil(t, err)
+	defer cli.Close()
+
+	conn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+	require.Nil(t, err)
+	defer conn.Close()
+	require.Equal(t, addrA, conn.Endpoint())
+	require.Equal(t, addrA, cli.ActiveEndpoint())
+
+	eps := cli.Endpoints()
+	require.Equal(t, 2, len(eps))
+	require.Equal(t, machrpc.EndpointStatus{Addr: addrA, Healthy: true, Conns: 1, Active: true}, eps[0])
+	require.Equal(t, machrpc.EndpointStatus{Addr: addrB, Healthy: true}, eps[1])
+
+	// the session moves to the other endpoint when the endpoint goes away
+	svrA.Stop()
+	_, err = conn.Ping()
+	require.Nil(t, err)
+	require.Equal(t, addrB, conn.Endpoint())
+	require.Equal(t, addrB, cli.ActiveEndpoint())
+
+	eps = cli.Endpoints()
+	require.False(t, eps[0].Healthy)
+	require.Equal(t, codes.Unavailable, status.Code(eps[0].LastError))
+	require.Equal(t, 0, eps[0].Conns)
+	require.Equal(t, 1, eps[1].Conns)
+	require.True(t, eps[1].Active)
+
+	// the new connection is made to the healthy endpoint
+	conn2, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+	require.Nil(t, err)
+	require.Equal(t, addrB, conn2.Endpoint())
+	require.Nil(t, conn2.Close())
+	require.Equal(t, 1, cli.Endpoints()[1].Conns)
+}
+
+func TestEndpointRoundRobin(t *testing.T) {
+	_, addrA := listenServer(t, "127.0.0.1:0")
+	_, addrB := listenServer(t, "127.0.0.1:0")
+
+	cli, err := machrpc.NewClient(&machrpc.Config{
+		Endpoints:      []string{addrA, addrB},
+		EndpointPolicy: machrpc.EndpointRoundRobin,
+	})
+	require.Nil(t, err)
+	defer cli.Close()
+
+	for i := 0; i < 4; i++ {
+		conn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+		require.Nil(t, err)
+		defer conn.Close()
+	}
+	eps := cli.Endpoints()
+	require.Equal(t, 2, eps[0].Conns)
+	require.Equal(t, 2, eps[1].Conns)
+}
+
+func TestEndpointHealthCheck(t *testing.T) {
+	svrA, addrA := listenServer(t, "127.0.0.1:0")
+	_, addrB := listenServer(t, "127.0.0.1:0")
+
+	newClient := func(policy machrpc.EndpointPolicy) *machrpc.Client {
+		cli, err := machrpc.NewClient(&machrpc.Config{
+			Endpoints:           []string{addrA, addrB},
+			EndpointPolicy:      policy,
+			HealthCheckInterval: 10 * time.Millisecond,
+		})
+		require.Nil(t, err)
+		t.Cleanup(cli.Close)
+		return cli
+	}
+	failover := newClient(machrpc.EndpointFailover)
+	pickFirst := newClient(machrpc.EndpointPickFirst)
+	require.Equal(t, addrA, failover.ActiveEndpoint())
+	require.Equal(t, addrA, pickFirst.ActiveEndpoint())
+
+	// the probe finds the endpoint that went away
+	svrA.Stop()
+	for _, cli := range []*machrpc.Client{failover, pickFirst} {
+		require.Eventually(t, func() bool { return cli.ActiveEndpoint() == addrB }, 3*time.Second, 10*time.Millisecond)
+		conn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+		require.Nil(t, err)
+		require.Equal(t, addrB, conn.Endpoint())
+		require.Nil(t, conn.Close())
+	}
+
+	// failover goes back to the preferred endpoint when it recovers, pick-first stays
+	listenServer(t, addrA)
+	require.Eventually(t, func() bool { return failover.ActiveEndpoint() == addrA }, 3*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return pickFirst.Endpoints()[0].Healthy }, 3*time.Second, 10*time.Millisecond)
+	require.Equal(t, addrB, pickFirst.ActiveEndpoint())
+}
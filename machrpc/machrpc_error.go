@@ -0,0 +1,210 @@
+package machrpc
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorKind is the classification of an Error.
+type ErrorKind int
+
+const (
+	ErrorUnknown        ErrorKind = iota
+	ErrorAuth                     // authentication failure
+	ErrorTableNotFound            // the table does not exist
+	ErrorSyntax                   // syntax error of the SQL statement
+	ErrorSessionExpired           // the server lost the session of the connection
+	ErrorTimeout                  // the deadline exceeded
+	ErrorUnavailable              // the server is not available
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorAuth:
+		return "auth"
+	case ErrorTableNotFound:
+		return "table not found"
+	case ErrorSyntax:
+		return "syntax"
+	case ErrorSessionExpired:
+		return "session expired"
+	case ErrorTimeout:
+		return "timeout"
+	case ErrorUnavailable:
+		return "unavailable"
+	default:
+		return "unknown"
+	}
+}
+
+// Sentinel errors of the kinds of Error, use them with errors.Is.
+//
+//	if errors.Is(err, machrpc.ErrTableNotFound) {
+//		// create table
+//	}
+//
+// ErrSessionLost is the sentinel of ErrorSessionExpired.
+var (
+	ErrAuthFailed    = errors.New("authentication failed")
+	ErrTableNotFound = errors.New("table not found")
+	ErrSyntax        = errors.New("syntax error")
+	ErrTimeout       = errors.New("timeout")
+	ErrUnavailable   = errors.New("server unavailable")
+)
+
+// Error is the error of a RPC, it is reported by the server or by the transport.
+//
+//	var rpcErr *machrpc.Error
+//	if errors.As(err, &rpcErr) {
+//		fmt.Println(rpcErr.RPC, rpcErr.Kind, rpcErr.Reason)
+//	}
+type Error struct {
+	RPC    string    // name of the RPC, e.g. "Query"
+	Reason string    // reason reported by the server, or the message of the transport error
+	Elapse string    // elapsed time reported by the server
+	Kind   ErrorKind // classification of the error
+	Err    error     // the transport error, nil if the error is reported by the server
+}
+
+// Error returns the reason of the error prefixed by the RPC, e.g. "Query: table not found".
+func (e *Error) Error() string {
+	if e.RPC == "" {
+		return e.Reason
+	}
+	return e.RPC + ": " + e.Reason
+}
+
+// Unwrap returns the transport error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if the target is the sentinel error of the kind of e.
+// It also matches context.Canceled and context.DeadlineExceeded
+// if the RPC was aborted by the context.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case context.Canceled:
+		return status.Code(e.Err) == codes.Canceled
+	case context.DeadlineExceeded:
+		return status.Code(e.Err) == codes.DeadlineExceeded
+	}
+	switch e.Kind {
+	case ErrorAuth:
+		return target == ErrAuthFailed
+	case ErrorTableNotFound:
+		return target == ErrTableNotFound
+	case ErrorSyntax:
+		return target == ErrSyntax
+	case ErrorSessionExpired:
+		return target == ErrSessionLost
+	case ErrorTimeout:
+		return target == ErrTimeout
+	case ErrorUnavailable:
+		return target == ErrUnavailable
+	}
+	return false
+}
+
+// newServerError returns the error of the reason that the server reported.
+func newServerError(rpc string, reason string, elapse string) error {
+	if reason == "" {
+		reason = "unknown error"
+	}
+	return &Error{RPC: rpc, Reason: reason, Elapse: elapse, Kind: classifyReason(reason)}
+}
+
+// wrapError converts the transport error of the RPC into Error.
+// It returns err as it is if err is nil or already has Error.
+func wrapError(rpc string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		return err
+	}
+	kind := ErrorUnknown
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Unauthenticated, codes.PermissionDenied:
+			kind = ErrorAuth
+		case codes.DeadlineExceeded:
+			kind = ErrorTimeout
+		case codes.Unavailable:
+			kind = ErrorUnavailable
+		default:
+			kind = classifyReason(st.Message())
+		}
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		kind = ErrorTimeout
+	} else if errors.Is(err, ErrSessionLost) {
+		kind = ErrorSessionExpired
+	}
+	return &Error{RPC: rpc, Reason: err.Error(), Kind: kind, Err: err}
+}
+
+// reasonCodePrefix is the error code that the server may put before the reason, e.g. "MACH-ERR 2024 : ".
+var reasonCodePrefix = regexp.MustCompile(`^mach-err\s*\d+\s*:\s*`)
+
+// reasonKinds are the phrases of the reasons that the server reports.
+// They are anchored at the start of the reason, so that the names of the tables
+// and the columns in the reason (e.g. "column AUTHOR not found in table T") are not matched.
+var reasonKinds = []struct {
+	phrase *regexp.Regexp
+	kind   ErrorKind
+}{
+	{regexp.MustCompile(`^` + sessionLostReason + `\b`), ErrorSessionExpired},
+	{regexp.MustCompile(`^(invalid (username|user|password)\b|authentication failed)`), ErrorAuth},
+	{regexp.MustCompile(`^table\s+\S+\s+(does not exist|not exists?|not found)`), ErrorTableNotFound},
+	{regexp.MustCompile(`^syntax error`), ErrorSyntax},
+	{regexp.MustCompile(`^((query|statement) )?(timeout|timed out)\b`), ErrorTimeout},
+}
+
+// classifyReason returns the kind of the reason that the server reported.
+func classifyReason(reason string) ErrorKind {
+	r := strings.ToLower(strings.TrimSpace(reason))
+	r = reasonCodePrefix.ReplaceAllString(r, "")
+	for _, rk := range reasonKinds {
+		if rk.phrase.MatchString(r) {
+			return rk.kind
+		}
+	}
+	return ErrorUnknown
+}
+
+// joinErrors returns the error that wraps the non-nil errs, nil if there is none.
+// It is errors.Join of the standard library that github.com/pkg/errors does not have.
+func joinErrors(errs ...error) error {
+	var ret joinError
+	for _, err := range errs {
+		if err != nil {
+			ret = append(ret, err)
+		}
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+	return ret
+}
+
+type joinError []error
+
+// Error returns the messages of the errors separated by newlines.
+func (e joinError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors for errors.Is and errors.As.
+func (e joinError) Unwrap() []error {
+	return e
+}
@@ -27,6 +27,7 @@ const (
 	Machbase_Query_FullMethodName           = "/machrpc.Machbase/Query"
 	Machbase_Columns_FullMethodName         = "/machrpc.Machbase/Columns"
 	Machbase_RowsFetch_FullMethodName       = "/machrpc.Machbase/RowsFetch"
+	Machbase_RowsFetchStream_FullMethodName = "/machrpc.Machbase/RowsFetchStream"
 	Machbase_RowsClose_FullMethodName       = "/machrpc.Machbase/RowsClose"
 	Machbase_Appender_FullMethodName        = "/machrpc.Machbase/Appender"
 	Machbase_Append_FullMethodName          = "/machrpc.Machbase/Append"
@@ -50,6 +51,7 @@ type MachbaseClient interface {
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
 	Columns(ctx context.Context, in *RowsHandle, opts ...grpc.CallOption) (*ColumnsResponse, error)
 	RowsFetch(ctx context.Context, in *RowsHandle, opts ...grpc.CallOption) (*RowsFetchResponse, error)
+	RowsFetchStream(ctx context.Context, in *RowsFetchRequest, opts ...grpc.CallOption) (Machbase_RowsFetchStreamClient, error)
 	RowsClose(ctx context.Context, in *RowsHandle, opts ...grpc.CallOption) (*RowsCloseResponse, error)
 	Appender(ctx context.Context, in *AppenderRequest, opts ...grpc.CallOption) (*AppenderResponse, error)
 	Append(ctx context.Context, opts ...grpc.CallOption) (Machbase_AppendClient, error)
@@ -141,6 +143,38 @@ func (c *machbaseClient) RowsFetch(ctx context.Context, in *RowsHandle, opts ...
 	return out, nil
 }
 
+func (c *machbaseClient) RowsFetchStream(ctx context.Context, in *RowsFetchRequest, opts ...grpc.CallOption) (Machbase_RowsFetchStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Machbase_ServiceDesc.Streams[0], Machbase_RowsFetchStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &machbaseRowsFetchStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Machbase_RowsFetchStreamClient interface {
+	Recv() (*RowsFetchBatch, error)
+	grpc.ClientStream
+}
+
+type machbaseRowsFetchStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *machbaseRowsFetchStreamClient) Recv() (*RowsFetchBatch, error) {
+	m := new(RowsFetchBatch)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *machbaseClient) RowsClose(ctx context.Context, in *RowsHandle, opts ...grpc.CallOption) (*RowsCloseResponse, error) {
 	out := new(RowsCloseResponse)
 	err := c.cc.Invoke(ctx, Machbase_RowsClose_FullMethodName, in, out, opts...)
@@ -160,7 +194,7 @@ func (c *machbaseClient) Appender(ctx context.Context, in *AppenderRequest, opts
 }
 
 func (c *machbaseClient) Append(ctx context.Context, opts ...grpc.CallOption) (Machbase_AppendClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Machbase_ServiceDesc.Streams[0], Machbase_Append_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &Machbase_ServiceDesc.Streams[1], Machbase_Append_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -259,6 +293,7 @@ type MachbaseServer interface {
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
 	Columns(context.Context, *RowsHandle) (*ColumnsResponse, error)
 	RowsFetch(context.Context, *RowsHandle) (*RowsFetchResponse, error)
+	RowsFetchStream(*RowsFetchRequest, Machbase_RowsFetchStreamServer) error
 	RowsClose(context.Context, *RowsHandle) (*RowsCloseResponse, error)
 	Appender(context.Context, *AppenderRequest) (*AppenderResponse, error)
 	Append(Machbase_AppendServer) error
@@ -299,6 +334,9 @@ func (UnimplementedMachbaseServer) Columns(context.Context, *RowsHandle) (*Colum
 func (UnimplementedMachbaseServer) RowsFetch(context.Context, *RowsHandle) (*RowsFetchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RowsFetch not implemented")
 }
+func (UnimplementedMachbaseServer) RowsFetchStream(*RowsFetchRequest, Machbase_RowsFetchStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method RowsFetchStream not implemented")
+}
 func (UnimplementedMachbaseServer) RowsClose(context.Context, *RowsHandle) (*RowsCloseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RowsClose not implemented")
 }
@@ -483,6 +521,27 @@ func _Machbase_RowsFetch_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Machbase_RowsFetchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RowsFetchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MachbaseServer).RowsFetchStream(m, &machbaseRowsFetchStreamServer{stream})
+}
+
+type Machbase_RowsFetchStreamServer interface {
+	Send(*RowsFetchBatch) error
+	grpc.ServerStream
+}
+
+type machbaseRowsFetchStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *machbaseRowsFetchStreamServer) Send(m *RowsFetchBatch) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Machbase_RowsClose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RowsHandle)
 	if err := dec(in); err != nil {
@@ -726,6 +785,11 @@ var Machbase_ServiceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RowsFetchStream",
+			Handler:       _Machbase_RowsFetchStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Append",
 			Handler:       _Machbase_Append_Handler,
@@ -2,7 +2,6 @@ package machrpc
 
 import (
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,30 +16,26 @@ func MakeGrpcInsecureConn(addr string) (grpc.ClientConnInterface, error) {
 	return MakeGrpcConn(addr, nil)
 }
 
+// MakeGrpcTlsConn makes a gRPC connection with mutual TLS,
+// the server certificate is verified by the CA certificate of caCertPath.
 func MakeGrpcTlsConn(addr string, keyPath string, certPath string, caCertPath string) (grpc.ClientConnInterface, error) {
-	cert, err := os.ReadFile(caCertPath)
+	tc := &TlsConfig{ClientKey: keyPath, ClientCert: certPath, ServerCert: caCertPath}
+	creds, err := tc.credentials()
 	if err != nil {
 		return nil, err
 	}
-	certPool := x509.NewCertPool()
-	if !certPool.AppendCertsFromPEM(cert) {
-		return nil, fmt.Errorf("fail to load server CA cert")
-	}
+	return makeGrpcConn(addr, creds)
+}
 
-	tlsCert, err := tls.LoadX509KeyPair(certPath, keyPath)
-	if err != nil {
-		return nil, err
-	}
-	tlsConfig := &tls.Config{
-		RootCAs:            certPool,
-		Certificates:       []tls.Certificate{tlsCert},
-		InsecureSkipVerify: true,
+// MakeGrpcConn makes a gRPC connection, it uses TLS if tlsConfig is not nil.
+func MakeGrpcConn(addr string, tlsConfig *tls.Config) (grpc.ClientConnInterface, error) {
+	if tlsConfig == nil {
+		return makeGrpcConn(addr, insecure.NewCredentials())
 	}
-
-	return MakeGrpcConn(addr, tlsConfig)
+	return makeGrpcConn(addr, credentials.NewTLS(tlsConfig))
 }
 
-func MakeGrpcConn(addr string, tlsConfig *tls.Config) (grpc.ClientConnInterface, error) {
+func makeGrpcConn(addr string, creds credentials.TransportCredentials, opts ...grpc.DialOption) (grpc.ClientConnInterface, error) {
 	pwd, _ := os.Getwd()
 	if strings.HasPrefix(addr, "unix://../") {
 		addr = fmt.Sprintf("unix:///%s", filepath.Join(filepath.Dir(pwd), addr[len("unix://../"):]))
@@ -57,13 +52,9 @@ func MakeGrpcConn(addr string, tlsConfig *tls.Config) (grpc.ClientConnInterface,
 		addr = strings.TrimPrefix(addr, "tcp://")
 	}
 
-	if tlsConfig == nil || strings.HasPrefix(addr, "unix://") {
-		return grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	} else {
-		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
-		if err != nil {
-			return nil, err
-		}
-		return conn, nil
+	if strings.HasPrefix(addr, "unix://") {
+		// unix domain socket does not use TLS
+		creds = insecure.NewCredentials()
 	}
+	return grpc.Dial(addr, append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, opts...)...)
 }
@@ -0,0 +1,207 @@
+package machrpc
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// ParallelAppender appends records to a table over multiple append streams (shards).
+// Each shard has its own connection and Appender, records are routed to the shards by the key
+// of the record, so the records of the same key are appended in order.
+//
+// If AppenderSpool is given by ParallelAppenderOptions, each shard spools into its own
+// sub-directory "shard-N" of the directory of the table, and replays it when it is opened again.
+// Keep the number of shards to replay the records of all shards.
+//
+//	app, _ := client.ParallelAppender(ctx, "TAGDATA",
+//		machrpc.ParallelConnectOptions(machrpc.WithPassword("sys", "manager")),
+//		machrpc.ParallelShards(8))
+//	defer app.Close()
+//	app.Append("name", time.Now(), 3.14)
+type ParallelAppender struct {
+	tableName string
+	shards    []*parallelShard
+	shardKey  func(cols []any) string
+
+	closeOnce sync.Once
+	closeSucc int64
+	closeFail int64
+	closeErr  error
+
+	numShards    int
+	connOpts     []ConnectOption
+	appenderOpts []AppenderOption
+}
+
+type parallelShard struct {
+	conn     *Conn
+	appender *Appender
+}
+
+// ParallelAppenderStats is the combined statistics of the shards of a ParallelAppender.
+// The counters of AppenderStats are the sums of all shards, LastFlushLatency is the maximum.
+type ParallelAppenderStats struct {
+	AppenderStats
+	Shards   int             // number of shards
+	PerShard []AppenderStats // statistics of each shard
+}
+
+type ParallelAppenderOption func(*ParallelAppender)
+
+// DefaultParallelShards is the default number of shards of ParallelAppender.
+const DefaultParallelShards = 4
+
+// ParallelShards sets the number of shards (default DefaultParallelShards).
+func ParallelShards(n int) ParallelAppenderOption {
+	return func(p *ParallelAppender) {
+		p.numShards = n
+	}
+}
+
+// ParallelConnectOptions sets options that are used when the shards make connections.
+func ParallelConnectOptions(opts ...ConnectOption) ParallelAppenderOption {
+	return func(p *ParallelAppender) {
+		p.connOpts = append(p.connOpts, opts...)
+	}
+}
+
+// ParallelAppenderOptions sets options of the Appender of each shard.
+func ParallelAppenderOptions(opts ...AppenderOption) ParallelAppenderOption {
+	return func(p *ParallelAppender) {
+		p.appenderOpts = append(p.appenderOpts, opts...)
+	}
+}
+
+// ParallelShardKey sets the function that returns the key of a record to choose the shard.
+// The default key is the first column, that is the tag name of the tag table.
+func ParallelShardKey(fn func(cols []any) string) ParallelAppenderOption {
+	return func(p *ParallelAppender) {
+		p.shardKey = fn
+	}
+}
+
+func defaultShardKey(cols []any) string {
+	if len(cols) == 0 {
+		return ""
+	}
+	if s, ok := cols[0].(string); ok {
+		return s
+	}
+	return fmt.Sprint(cols[0])
+}
+
+// ParallelAppender creates a new ParallelAppender of the table.
+// ParallelAppender should be closed otherwise it may cause server side resource leak.
+func (client *Client) ParallelAppender(ctx context.Context, tableName string, opts ...ParallelAppenderOption) (*ParallelAppender, error) {
+	p := &ParallelAppender{
+		numShards: DefaultParallelShards,
+		shardKey:  defaultShardKey,
+	}
+	for _, o := range opts {
+		o(p)
+	}
+	if p.numShards < 1 {
+		p.numShards = 1
+	}
+	for i := 0; i < p.numShards; i++ {
+		conn, err := client.Connect(ctx, p.connOpts...)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		opts := append(append([]AppenderOption{}, p.appenderOpts...), appenderSpoolSubdir(fmt.Sprintf("shard-%d", i)))
+		appender, err := conn.Appender(ctx, tableName, opts...)
+		if err != nil {
+			conn.Close()
+			p.Close()
+			return nil, err
+		}
+		p.shards = append(p.shards, &parallelShard{conn: conn, appender: appender})
+	}
+	p.tableName = p.shards[0].appender.TableName()
+	return p, nil
+}
+
+// appenderSpoolSubdir sets the sub-directory of the spool of the shard.
+func appenderSpoolSubdir(dir string) AppenderOption {
+	return func(a *Appender) {
+		a.spoolSubdir = dir
+	}
+}
+
+func (p *ParallelAppender) shard(cols []any) *parallelShard {
+	if len(p.shards) == 1 {
+		return p.shards[0]
+	}
+	h := fnv.New32a()
+	h.Write([]byte(p.shardKey(cols)))
+	return p.shards[h.Sum32()%uint32(len(p.shards))]
+}
+
+// Append appends a new record to the shard of the key of the record.
+func (p *ParallelAppender) Append(cols ...any) error {
+	return p.shard(cols).appender.Append(cols...)
+}
+
+// TableName returns the name of the table.
+func (p *ParallelAppender) TableName() string {
+	return p.tableName
+}
+
+// Flush sends the buffered records of all shards synchronously.
+func (p *ParallelAppender) Flush(ctx context.Context) error {
+	var errs []error
+	for _, s := range p.shards {
+		if err := s.appender.Flush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs...)
+}
+
+// Stats returns the combined statistics of the shards.
+func (p *ParallelAppender) Stats() ParallelAppenderStats {
+	ret := ParallelAppenderStats{Shards: len(p.shards), PerShard: make([]AppenderStats, len(p.shards))}
+	for i, s := range p.shards {
+		st := s.appender.Stats()
+		ret.PerShard[i] = st
+		ret.Appended += st.Appended
+		ret.Sent += st.Sent
+		ret.Batches += st.Batches
+		ret.BytesSent += st.BytesSent
+		ret.Buffered += st.Buffered
+		ret.Dropped += st.Dropped
+		ret.Spooled += st.Spooled
+		ret.SendErrors += st.SendErrors
+		ret.ServerSuccess += st.ServerSuccess
+		ret.ServerFail += st.ServerFail
+		if st.LastFlushLatency > ret.LastFlushLatency {
+			ret.LastFlushLatency = st.LastFlushLatency
+		}
+	}
+	return ret
+}
+
+// Close closes the appenders and the connections of all shards,
+// it returns the total number of records that the server appended and failed to append.
+// It is safe to call Close concurrently, the later calls return the same result.
+func (p *ParallelAppender) Close() (int64, int64, error) {
+	p.closeOnce.Do(func() {
+		var errs []error
+		for _, s := range p.shards {
+			sc, fc, err := s.appender.Close()
+			p.closeSucc += sc
+			p.closeFail += fc
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if err := s.conn.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		p.closeErr = joinErrors(errs...)
+	})
+	return p.closeSucc, p.closeFail, p.closeErr
+}
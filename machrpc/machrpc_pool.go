@@ -0,0 +1,440 @@
+package machrpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrPoolClosed is returned by Pool.Get when the pool has been closed.
+var ErrPoolClosed = errors.New("connection pool is closed")
+
+// defaultHealthCheckIdle is the default of PoolHealthCheck,
+// the connections that are idle for a short time are handed out without the round trip.
+const defaultHealthCheckIdle = 10 * time.Second
+
+// Pool is a set of reusable Conn that is safe for concurrent use by multiple goroutines.
+//
+//	pool, _ := machrpc.NewPool(client,
+//		machrpc.PoolConnectOptions(machrpc.WithPassword("sys", "manager")),
+//		machrpc.PoolMaxOpenConns(10),
+//	)
+//	defer pool.Close()
+//
+//	conn, _ := pool.Get(ctx)
+//	defer pool.Put(conn)
+type Pool struct {
+	client   *Client
+	connOpts []ConnectOption
+
+	maxOpen         int
+	maxIdle         int
+	maxIdleTime     time.Duration
+	maxLifetime     time.Duration
+	healthCheckIdle time.Duration
+
+	mu       sync.Mutex
+	idle     []*Conn
+	numOpen  int
+	waiters  []chan connRequest
+	closed   bool
+	closeCh  chan struct{}
+	stats    PoolStats
+	cleanerW sync.WaitGroup
+}
+
+type connRequest struct {
+	conn *Conn
+	err  error
+}
+
+// PoolStats holds statistics of a Pool.
+type PoolStats struct {
+	MaxOpenConns int // Maximum number of open connections, 0 means unlimited
+
+	OpenConns int // The number of established connections both in use and idle
+	InUse     int // The number of connections currently in use
+	Idle      int // The number of idle connections
+
+	WaitCount          int64         // The total number of connections waited for
+	WaitDuration       time.Duration // The total time blocked waiting for a new connection
+	MaxIdleClosed      int64         // The total number of connections closed due to PoolMaxIdleConns
+	MaxIdleTimeClosed  int64         // The total number of connections closed due to PoolMaxIdleTime
+	MaxLifetimeClosed  int64         // The total number of connections closed due to PoolMaxLifetime
+	HealthCheckFailed  int64         // The total number of connections closed due to failed health check
+	ConnectionsCreated int64         // The total number of connections created by the pool
+}
+
+type PoolOption func(*Pool)
+
+// PoolConnectOptions sets options that are used when the pool makes a new connection.
+func PoolConnectOptions(opts ...ConnectOption) PoolOption {
+	return func(p *Pool) {
+		p.connOpts = append(p.connOpts, opts...)
+	}
+}
+
+// PoolMaxOpenConns sets the maximum number of open connections.
+// If n <= 0, there is no limit (default).
+func PoolMaxOpenConns(n int) PoolOption {
+	return func(p *Pool) {
+		p.maxOpen = n
+	}
+}
+
+// PoolMaxIdleConns sets the maximum number of connections in the idle pool (default 2).
+// If n <= 0, no idle connections are retained.
+func PoolMaxIdleConns(n int) PoolOption {
+	return func(p *Pool) {
+		p.maxIdle = n
+	}
+}
+
+// PoolMaxIdleTime sets the maximum amount of time a connection may be idle.
+// Expired connections are closed lazily before reuse and by the background cleaner.
+func PoolMaxIdleTime(d time.Duration) PoolOption {
+	return func(p *Pool) {
+		p.maxIdleTime = d
+	}
+}
+
+// PoolMaxLifetime sets the maximum amount of time a connection may be reused.
+func PoolMaxLifetime(d time.Duration) PoolOption {
+	return func(p *Pool) {
+		p.maxLifetime = d
+	}
+}
+
+// PoolHealthCheck sets the idle duration after which a connection is checked with Conn.PingContext
+// before it is handed out by Pool.Get (default 10s). If d is 0, every idle connection is checked.
+// If d < 0, health check is disabled.
+func PoolHealthCheck(d time.Duration) PoolOption {
+	return func(p *Pool) {
+		p.healthCheckIdle = d
+	}
+}
+
+// NewPool creates a new connection pool that makes connections with the given client.
+func NewPool(client *Client, opts ...PoolOption) (*Pool, error) {
+	if client == nil {
+		return nil, errors.New("nil client")
+	}
+	p := &Pool{
+		client:          client,
+		maxIdle:         2,
+		healthCheckIdle: defaultHealthCheckIdle,
+		closeCh:         make(chan struct{}),
+	}
+	for _, o := range opts {
+		o(p)
+	}
+	if p.maxOpen > 0 && p.maxIdle > p.maxOpen {
+		p.maxIdle = p.maxOpen
+	}
+	if interval := p.cleanerInterval(); interval > 0 {
+		p.cleanerW.Add(1)
+		go p.cleaner(interval)
+	}
+	return p, nil
+}
+
+// Get returns an idle connection of the pool or creates a new one.
+// If the pool reached PoolMaxOpenConns, it waits until a connection is returned
+// or the ctx is done.
+// The returned Conn should be returned by Put() or Discard().
+func (p *Pool) Get(ctx context.Context) (*Conn, error) {
+	for {
+		conn, err := p.get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if conn == nil {
+			// a slot is reserved for this request, make a new connection
+			newConn, err := p.client.Connect(ctx, p.connOpts...)
+			if err != nil {
+				p.release()
+				return nil, err
+			}
+			newConn.createdAt = time.Now()
+			p.mu.Lock()
+			p.stats.ConnectionsCreated++
+			p.mu.Unlock()
+			return newConn, nil
+		}
+		if !p.healthy(ctx, conn) {
+			p.mu.Lock()
+			p.stats.HealthCheckFailed++
+			p.mu.Unlock()
+			p.Discard(conn)
+			continue
+		}
+		return conn, nil
+	}
+}
+
+// get returns an idle connection, or nil Conn without error that represents
+// a slot is reserved for the caller to make a new connection.
+func (p *Pool) get(ctx context.Context) (*Conn, error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrPoolClosed
+	}
+	if err := ctx.Err(); err != nil {
+		p.mu.Unlock()
+		return nil, err
+	}
+
+	now := time.Now()
+	var expired []*Conn
+	for len(p.idle) > 0 {
+		conn := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if p.evictableLocked(conn, now) {
+			p.numOpen--
+			p.openForWaiterLocked()
+			expired = append(expired, conn)
+			continue
+		}
+		p.mu.Unlock()
+		closeConns(expired)
+		return conn, nil
+	}
+
+	if p.maxOpen <= 0 || p.numOpen < p.maxOpen {
+		p.numOpen++
+		p.mu.Unlock()
+		closeConns(expired)
+		return nil, nil
+	}
+
+	req := make(chan connRequest, 1)
+	p.waiters = append(p.waiters, req)
+	p.stats.WaitCount++
+	p.mu.Unlock()
+	closeConns(expired)
+
+	waitStart := time.Now()
+	select {
+	case <-ctx.Done():
+		p.mu.Lock()
+		p.stats.WaitDuration += time.Since(waitStart)
+		removed := false
+		for i, w := range p.waiters {
+			if w == req {
+				p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+				removed = true
+				break
+			}
+		}
+		p.mu.Unlock()
+		if !removed {
+			// the request has been fulfilled while the ctx is done
+			if ret := <-req; ret.err == nil {
+				if ret.conn != nil {
+					p.Put(ret.conn)
+				} else {
+					p.release()
+				}
+			}
+		}
+		return nil, ctx.Err()
+	case ret := <-req:
+		p.mu.Lock()
+		p.stats.WaitDuration += time.Since(waitStart)
+		p.mu.Unlock()
+		if ret.err != nil {
+			return nil, ret.err
+		}
+		return ret.conn, nil
+	}
+}
+
+// Put returns the conn to the pool.
+func (p *Pool) Put(conn *Conn) {
+	if conn == nil {
+		return
+	}
+	p.mu.Lock()
+	if p.closed || p.expiredLocked(conn, time.Now()) {
+		p.mu.Unlock()
+		p.Discard(conn)
+		return
+	}
+	if len(p.waiters) > 0 {
+		req := p.waiters[0]
+		p.waiters = p.waiters[1:]
+		p.mu.Unlock()
+		req <- connRequest{conn: conn}
+		return
+	}
+	if len(p.idle) >= p.maxIdle {
+		p.stats.MaxIdleClosed++
+		p.numOpen--
+		p.mu.Unlock()
+		conn.Close()
+		return
+	}
+	conn.returnedAt = time.Now()
+	p.idle = append(p.idle, conn)
+	p.mu.Unlock()
+}
+
+// Discard closes the conn instead of returning it to the pool.
+// It should be used when the conn is known to be broken.
+func (p *Pool) Discard(conn *Conn) {
+	if conn == nil {
+		return
+	}
+	p.release()
+	conn.Close()
+}
+
+// release frees a slot of an open connection.
+func (p *Pool) release() {
+	p.mu.Lock()
+	p.numOpen--
+	p.openForWaiterLocked()
+	p.mu.Unlock()
+}
+
+// Close closes all idle connections and prevents new Get.
+// Connections in use are closed when they are returned to the pool.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.closeCh)
+	idle := p.idle
+	p.idle = nil
+	p.numOpen -= len(idle)
+	for _, req := range p.waiters {
+		req <- connRequest{err: ErrPoolClosed}
+	}
+	p.waiters = nil
+	p.mu.Unlock()
+
+	p.cleanerW.Wait()
+
+	var err error
+	for _, conn := range idle {
+		if e := conn.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// Stats returns statistics of the pool.
+func (p *Pool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ret := p.stats
+	ret.MaxOpenConns = p.maxOpen
+	ret.OpenConns = p.numOpen
+	ret.Idle = len(p.idle)
+	ret.InUse = p.numOpen - len(p.idle)
+	return ret
+}
+
+// openForWaiterLocked hands over a free slot to the first waiter.
+func (p *Pool) openForWaiterLocked() {
+	if len(p.waiters) == 0 || p.closed {
+		return
+	}
+	if p.maxOpen > 0 && p.numOpen >= p.maxOpen {
+		return
+	}
+	req := p.waiters[0]
+	p.waiters = p.waiters[1:]
+	p.numOpen++
+	req <- connRequest{}
+}
+
+// expiredLocked returns true if the conn exceeds PoolMaxLifetime.
+func (p *Pool) expiredLocked(conn *Conn, now time.Time) bool {
+	if p.maxLifetime > 0 && now.Sub(conn.createdAt) > p.maxLifetime {
+		p.stats.MaxLifetimeClosed++
+		return true
+	}
+	return false
+}
+
+// evictableLocked returns true if the idle conn exceeds PoolMaxLifetime or PoolMaxIdleTime.
+func (p *Pool) evictableLocked(conn *Conn, now time.Time) bool {
+	if p.expiredLocked(conn, now) {
+		return true
+	}
+	if p.maxIdleTime > 0 && now.Sub(conn.returnedAt) > p.maxIdleTime {
+		p.stats.MaxIdleTimeClosed++
+		return true
+	}
+	return false
+}
+
+func (p *Pool) healthy(ctx context.Context, conn *Conn) bool {
+	if p.healthCheckIdle < 0 {
+		return true
+	}
+	if p.healthCheckIdle > 0 && time.Since(conn.returnedAt) < p.healthCheckIdle {
+		return true
+	}
+	_, err := conn.PingContext(ctx)
+	return err == nil
+}
+
+func (p *Pool) cleanerInterval() time.Duration {
+	d := p.maxIdleTime
+	if d <= 0 || (p.maxLifetime > 0 && p.maxLifetime < d) {
+		d = p.maxLifetime
+	}
+	if d <= 0 {
+		return 0
+	}
+	if d = d / 2; d < 10*time.Millisecond {
+		d = 10 * time.Millisecond
+	}
+	return d
+}
+
+func (p *Pool) cleaner(interval time.Duration) {
+	defer p.cleanerW.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.closeCh:
+			return
+		case <-ticker.C:
+		}
+		now := time.Now()
+		var evicted []*Conn
+		p.mu.Lock()
+		remains := p.idle[:0]
+		for _, conn := range p.idle {
+			if p.evictableLocked(conn, now) {
+				evicted = append(evicted, conn)
+			} else {
+				remains = append(remains, conn)
+			}
+		}
+		p.idle = remains
+		p.numOpen -= len(evicted)
+		for range evicted {
+			p.openForWaiterLocked()
+		}
+		p.mu.Unlock()
+		closeConns(evicted)
+	}
+}
+
+func closeConns(conns []*Conn) {
+	for _, c := range conns {
+		c.Close()
+	}
+}
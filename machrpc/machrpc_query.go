@@ -0,0 +1,131 @@
+package machrpc
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// QueryAll executes the query and returns all rows decoded into T.
+// T can be a struct (or pointer to struct) that is decoded like Rows.ScanStruct,
+// map[string]any that holds values by column names (NULL is nil), or a scalar type of the first column.
+//
+//	recs, err := machrpc.QueryAll[Record](ctx, conn, "select * from example where name = ?", "my_name")
+func QueryAll[T any](ctx context.Context, conn *Conn, sqlText string, params ...any) ([]T, error) {
+	ret := []T{}
+	err := QueryEach(ctx, conn, sqlText, func(v T) error {
+		ret = append(ret, v)
+		return nil
+	}, params...)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// QueryOne executes the query and returns the first row decoded into T.
+// It returns sql.ErrNoRows if the query has no result.
+//
+//	cnt, err := machrpc.QueryOne[int](ctx, conn, "select count(*) from example")
+func QueryOne[T any](ctx context.Context, conn *Conn, sqlText string, params ...any) (T, error) {
+	var ret T
+	found := false
+	err := QueryEach(ctx, conn, sqlText, func(v T) error {
+		ret, found = v, true
+		return errStopQueryEach
+	}, params...)
+	if err != nil && err != errStopQueryEach {
+		return ret, err
+	}
+	if !found {
+		return ret, sql.ErrNoRows
+	}
+	return ret, nil
+}
+
+// errStopQueryEach stops QueryEach without error.
+var errStopQueryEach = errors.New("stop query each")
+
+// QueryEach executes the query and calls fn with each row decoded into T.
+// If fn returns an error, QueryEach stops and returns the error.
+// The Rows of the query is always closed before QueryEach returns.
+func QueryEach[T any](ctx context.Context, conn *Conn, sqlText string, fn func(T) error, params ...any) (err error) {
+	rows, err := conn.Query(ctx, sqlText, params...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := rows.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	decode, err := rowDecoder[T](rows)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var v T
+		if err := decode(&v); err != nil {
+			return err
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	mapType     = reflect.TypeOf(map[string]any{})
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+// rowDecoder returns the function that decodes the current row of rows into T.
+func rowDecoder[T any](rows *Rows) (func(*T) error, error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	isStruct := func(t reflect.Type) bool {
+		return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(scannerType)
+	}
+
+	switch {
+	case typ == mapType:
+		names, _, err := rows.Columns()
+		if err != nil {
+			return nil, err
+		}
+		return func(v *T) error {
+			m := make(map[string]any, len(names))
+			for i, name := range names {
+				if i < len(rows.values) && !isNullValue(rows.values[i]) {
+					m[name] = rows.values[i]
+				} else {
+					m[name] = nil
+				}
+			}
+			*any(v).(*map[string]any) = m
+			return nil
+		}, nil
+	case isStruct(typ):
+		return func(v *T) error {
+			return rows.ScanStruct(v)
+		}, nil
+	case typ.Kind() == reflect.Pointer && isStruct(typ.Elem()):
+		return func(v *T) error {
+			elem := reflect.New(typ.Elem())
+			if err := rows.ScanStruct(elem.Interface()); err != nil {
+				return err
+			}
+			reflect.ValueOf(v).Elem().Set(elem)
+			return nil
+		}, nil
+	default:
+		return func(v *T) error {
+			return rows.Scan(v)
+		}, nil
+	}
+}
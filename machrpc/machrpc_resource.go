@@ -0,0 +1,183 @@
+package machrpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrClientClosed is returned when a connection is requested to the closed Client.
+var ErrClientClosed = errors.New("client is closed")
+
+// defaultCloseTimeout is the deadline of Client.Close.
+const defaultCloseTimeout = 10 * time.Second
+
+// OpenResource describes a Conn, Rows or Appender of the Client that is not closed yet.
+type OpenResource struct {
+	Kind      string // "Appender", "Rows" or "Conn"
+	Name      string // table name of the Appender, handle of the Rows or the Conn
+	CreatedAt time.Time
+}
+
+func (r OpenResource) String() string {
+	return fmt.Sprintf("%s %q opened at %s", r.Kind, r.Name, r.CreatedAt.Format(time.RFC3339Nano))
+}
+
+// closeOrder is the order of the kinds to close, the children are closed before the parents.
+var closeOrder = map[string]int{"Appender": 0, "Rows": 1, "Conn": 2}
+
+// resource is a child of the Client that is closed by Client.CloseContext.
+type resource interface {
+	closeResource(ctx context.Context) error
+}
+
+func (appender *Appender) closeResource(ctx context.Context) error {
+	err := appender.Flush(ctx)
+	if _, _, closeErr := appender.Close(); closeErr != nil {
+		err = joinErrors(err, closeErr)
+	}
+	return err
+}
+
+func (rows *Rows) closeResource(ctx context.Context) error {
+	if rows.stopCancel != nil {
+		rows.stopCancel()
+	}
+	// the stream is not cancelled here, it may be used by other goroutine,
+	// it is terminated when the client closes the transport.
+	rows.closeOnce.Do(func() {
+		rows.closeErr = rows.release()
+	})
+	return rows.closeErr
+}
+
+func (conn *Conn) closeResource(ctx context.Context) error {
+	return conn.CloseContext(ctx)
+}
+
+// track registers the resource to the client, it returns ErrClientClosed if the client is closed.
+func (client *Client) track(r resource, kind string, name string) error {
+	client.resourcesLock.Lock()
+	defer client.resourcesLock.Unlock()
+	if client.closed {
+		return ErrClientClosed
+	}
+	if client.resources == nil {
+		client.resources = map[resource]OpenResource{}
+	}
+	client.resources[r] = OpenResource{Kind: kind, Name: name, CreatedAt: time.Now()}
+	return nil
+}
+
+func (client *Client) isClosed() bool {
+	client.resourcesLock.Lock()
+	defer client.resourcesLock.Unlock()
+	return client.closed
+}
+
+// untrack removes the resource from the client when it is closed.
+func (client *Client) untrack(r resource) {
+	client.resourcesLock.Lock()
+	defer client.resourcesLock.Unlock()
+	delete(client.resources, r)
+}
+
+// OpenResources returns the Conn, Rows and Appender of the client that are not closed yet,
+// in the order of creation.
+func (client *Client) OpenResources() []OpenResource {
+	client.resourcesLock.Lock()
+	defer client.resourcesLock.Unlock()
+	ret := make([]OpenResource, 0, len(client.resources))
+	for _, r := range client.resources {
+		ret = append(ret, r)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].CreatedAt.Before(ret[j].CreatedAt) })
+	return ret
+}
+
+// CloseContext flushes and closes the appenders, closes the rows and the connections
+// that are still open, then closes the gRPC connection of the client.
+// If ctx is done before all of them are closed, the gRPC connection is closed anyway
+// and the pending calls are aborted.
+//
+// It returns the resources that were still open, which the application leaked.
+//
+//	leaks, err := client.CloseContext(ctx)
+//	for _, r := range leaks {
+//		log.Println("leak:", r)
+//	}
+func (client *Client) CloseContext(ctx context.Context) ([]OpenResource, error) {
+	client.resourcesLock.Lock()
+	if client.closed {
+		client.resourcesLock.Unlock()
+		return nil, nil
+	}
+	client.closed = true
+	type entry struct {
+		res resource
+		OpenResource
+	}
+	entries := make([]entry, 0, len(client.resources))
+	for res, r := range client.resources {
+		entries = append(entries, entry{res: res, OpenResource: r})
+	}
+	client.resourcesLock.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if oi, oj := closeOrder[entries[i].Kind], closeOrder[entries[j].Kind]; oi != oj {
+			return oi < oj
+		}
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	leaks := make([]OpenResource, len(entries))
+	for i, e := range entries {
+		leaks[i] = e.OpenResource
+		if client.logger != nil {
+			client.logger.Warn("machrpc resource is not closed", "kind", e.Kind, "name", e.Name, "created", e.CreatedAt)
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		var errs []error
+		for _, e := range entries {
+			if err := ctx.Err(); err != nil {
+				errs = append(errs, err)
+				break
+			}
+			if err := e.res.closeResource(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("close %s %q: %w", e.Kind, e.Name, err))
+			}
+		}
+		done <- joinErrors(errs...)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	close(client.closeCh)
+	if closeErr := client.closeEndpoints(); closeErr != nil {
+		err = joinErrors(err, closeErr)
+	}
+	return leaks, err
+}
+
+// closeEndpoints closes the gRPC connections of the endpoints.
+func (client *Client) closeEndpoints() error {
+	var errs []error
+	for _, ep := range client.endpoints {
+		if closer, ok := ep.conn.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return joinErrors(errs...)
+}
@@ -0,0 +1,253 @@
+package machrpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrSessionLost is returned when the server lost the session of the connection
+// (e.g. the server restarted) while a non-idempotent call was in progress.
+// The connection re-establishes its session on the next call.
+var ErrSessionLost = errors.New("session lost")
+
+// RetryPolicy describes how idempotent calls are retried when they fail
+// with a transient error.
+//
+//	client, _ := machrpc.NewClient(&machrpc.Config{
+//		ServerAddr: "127.0.0.1:5655",
+//		Retry:      machrpc.DefaultRetryPolicy(),
+//	})
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// Retry is disabled if it is less than 2.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper bound of the delay between retries.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay grows by after each retry.
+	Multiplier float64
+	// Jitter randomizes the delay by the given fraction [0, 1].
+	Jitter float64
+	// RetryableCodes are the gRPC status codes that are considered transient.
+	// If it is empty, codes.Unavailable and codes.Aborted are used.
+	RetryableCodes []codes.Code
+}
+
+// DefaultRetryPolicy returns a RetryPolicy that makes up to 5 attempts
+// with exponential backoff from 100ms to 5s.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2.0,
+		Jitter:         0.2,
+	}
+}
+
+func (rp *RetryPolicy) maxAttempts() int {
+	if rp == nil || rp.MaxAttempts < 1 {
+		return 1
+	}
+	return rp.MaxAttempts
+}
+
+// backoff returns the delay before the n-th retry (n starts from 1).
+func (rp *RetryPolicy) backoff(n int) time.Duration {
+	mult := rp.Multiplier
+	if mult < 1 {
+		mult = 1
+	}
+	delay := float64(rp.InitialBackoff) * math.Pow(mult, float64(n-1))
+	if rp.MaxBackoff > 0 && delay > float64(rp.MaxBackoff) {
+		delay = float64(rp.MaxBackoff)
+	}
+	if rp.Jitter > 0 {
+		jitter := math.Min(rp.Jitter, 1)
+		delay = delay * (1 - jitter + 2*jitter*rand.Float64())
+	}
+	return time.Duration(delay)
+}
+
+func (rp *RetryPolicy) retryable(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	var retryableCodes []codes.Code
+	if rp != nil {
+		retryableCodes = rp.RetryableCodes
+	}
+	if len(retryableCodes) == 0 {
+		retryableCodes = []codes.Code{codes.Unavailable, codes.Aborted}
+	}
+	for _, c := range retryableCodes {
+		if st.Code() == c {
+			return true
+		}
+	}
+	return false
+}
+
+// wait blocks for the backoff of the n-th retry or until the ctx is done.
+func (rp *RetryPolicy) wait(ctx context.Context, n int) error {
+	timer := time.NewTimer(rp.backoff(n))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// sessionLostReason is the prefix of the reason that the server reports
+// when it does not know the connection handle, e.g. after it restarted.
+// The server has no status code for it, so the lost session is recognized only by this text,
+// the mock server of the tests reports the same reason.
+const sessionLostReason = "invalid connection"
+
+// isSessionLost returns true if the server does not know the connection handle any more.
+func isSessionLost(err error) bool {
+	if err == nil {
+		return false
+	}
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) && rpcErr.Err == nil {
+		// the reason reported by the server, Error() has the prefix of the RPC
+		return rpcErr.Kind == ErrorSessionExpired
+	}
+	if _, ok := status.FromError(err); ok {
+		return false
+	}
+	return strings.HasPrefix(strings.ToLower(err.Error()), sessionLostReason)
+}
+
+// retry calls fn until it succeeds or the retry policy of the client is exhausted.
+func (client *Client) retry(ctx context.Context, fn func() error) error {
+	policy := client.retryPolicy
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= policy.maxAttempts() || !policy.retryable(err) {
+			return err
+		}
+		if werr := policy.wait(ctx, attempt); werr != nil {
+			return err
+		}
+	}
+}
+
+// invoke calls fn with the handle of the connection.
+// If the server lost the session, the handle is re-established on the next call.
+// Idempotent calls are retried according to the RetryPolicy of the client.
+// Non-idempotent calls are not retried, they return ErrSessionLost if the server lost the session,
+// or the transient error as it is since the session may be still alive.
+func (conn *Conn) invoke(ctx context.Context, idempotent bool, fn func(cli MachbaseClient, handle *ConnHandle) error) error {
+	policy := conn.client.retryPolicy
+	for attempt := 1; ; attempt++ {
+		ep, handle, err := conn.currentHandle(ctx)
+		if err == nil {
+			err = fn(ep.cli, handle)
+			if err == nil {
+				return nil
+			}
+			if isUnavailable(err) {
+				ep.setHealth(err)
+			}
+		}
+		lost := isSessionLost(err)
+		transient := policy.retryable(err)
+		if lost || transient {
+			conn.markSuspect(handle, lost)
+		}
+		if !idempotent {
+			if lost {
+				return fmt.Errorf("%w: %w", ErrSessionLost, err)
+			}
+			return err
+		}
+		if (!lost && !transient) || attempt >= policy.maxAttempts() {
+			return err
+		}
+		if lost && attempt == 1 {
+			// the session can be re-established immediately
+			continue
+		}
+		if werr := policy.wait(ctx, attempt); werr != nil {
+			return err
+		}
+	}
+}
+
+// currentHandle returns the endpoint and the handle of the connection,
+// it verifies or re-establishes the session if the previous call failed.
+// If the endpoint of the session is not available, the session is re-established
+// on other endpoint that the EndpointPolicy of the client selects.
+func (conn *Conn) currentHandle(ctx context.Context) (*endpoint, *ConnHandle, error) {
+	conn.handleLock.Lock()
+	defer conn.handleLock.Unlock()
+
+	if conn.suspect && !conn.lost {
+		// the previous call failed by a transport error, check if the session is still alive
+		rsp, err := conn.ep.cli.Ping(ctx, &PingRequest{Conn: conn.handle, Token: time.Now().UnixNano()})
+		if err != nil {
+			if !isUnavailable(err) || len(conn.client.endpoints) == 1 {
+				return nil, nil, err
+			}
+			// the endpoint has gone, move to other endpoint
+			conn.ep.setHealth(err)
+			conn.lost = true
+		} else if rsp.Success {
+			conn.suspect = false
+			return conn.ep, conn.handle, nil
+		} else {
+			conn.lost = true
+		}
+	}
+	if conn.lost {
+		ep, rsp, err := conn.client.connect(ctx, &ConnRequest{User: conn.dbUser, Password: conn.dbPassword})
+		if err != nil {
+			return nil, nil, err
+		}
+		if !rsp.Success {
+			return nil, nil, newServerError("Conn", rsp.Reason, rsp.Elapse)
+		}
+		conn.bind(ep, rsp.Conn)
+		conn.suspect, conn.lost = false, false
+	}
+	return conn.ep, conn.handle, nil
+}
+
+// bind sets the session of the connection, the caller should hold handleLock
+// unless the connection is not returned to the caller yet.
+func (conn *Conn) bind(ep *endpoint, handle *ConnHandle) {
+	if !conn.closed {
+		if conn.ep != nil {
+			conn.ep.conns.Add(-1)
+		}
+		ep.conns.Add(1)
+	}
+	conn.ep, conn.handle = ep, handle
+}
+
+func (conn *Conn) markSuspect(handle *ConnHandle, lost bool) {
+	conn.handleLock.Lock()
+	defer conn.handleLock.Unlock()
+	if handle == nil || conn.handle != handle {
+		// the handle is already replaced by other call
+		return
+	}
+	conn.suspect = true
+	if lost {
+		conn.lost = true
+	}
+}
@@ -1,19 +1,94 @@
 package machrpc
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
 	"math"
 	"net"
+	"reflect"
 	"strconv"
 	"time"
-
-	"github.com/pkg/errors"
 )
 
+// ScanNull sets NULL into c.
+// c should be one of sql.Scanner (e.g. *sql.NullString), pointer to pointer (e.g. **int),
+// *any, *driver.Value or *[]byte.
+func ScanNull(c any) error {
+	switch cv := c.(type) {
+	case sql.Scanner:
+		return cv.Scan(nil)
+	case *any:
+		*cv = nil
+		return nil
+	case *driver.Value:
+		*cv = nil
+		return nil
+	case *[]byte:
+		*cv = nil
+		return nil
+	}
+	if rv := reflect.ValueOf(c); rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Pointer {
+		rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+		return nil
+	}
+	return fmt.Errorf("scan NULL into %T not supported, use sql.Null* or pointer type", c)
+}
+
+// isNullValue returns true if v is NULL or the value that represents NULL of the numeric column.
+func isNullValue(v any) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case int16:
+		return x == math.MinInt16
+	case int32:
+		return x == math.MinInt32
+	case int64:
+		return x == math.MinInt64
+	}
+	return false
+}
+
+// scanConvertFallback scans v into sql.Scanner or pointer to pointer,
+// other types of c are not supported.
+func scanConvertFallback(v any, c any, from string) error {
+	if _, ok := c.(sql.Scanner); ok {
+		return scanValue(v, c)
+	}
+	if rv := reflect.ValueOf(c); rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Pointer {
+		return scanValue(v, c)
+	}
+	return fmt.Errorf("scan convert from %s to %T not supported", from, c)
+}
+
+// driverValue converts v into one of the types that sql.Scanner accepts.
+func driverValue(v any) driver.Value {
+	if isNullValue(v) {
+		return nil
+	}
+	switch x := v.(type) {
+	case int:
+		return int64(x)
+	case int16:
+		return int64(x)
+	case int32:
+		return int64(x)
+	case uint32:
+		return int64(x)
+	case uint64:
+		return int64(x)
+	case float32:
+		return float64(x)
+	case net.IP:
+		return x.String()
+	}
+	return v
+}
+
 func ScanInt16(v int16, c any) error {
 	if v == math.MinInt16 {
-		return errors.New("scan NULL INT16")
+		return ScanNull(c)
 	}
 	switch cv := c.(type) {
 	case *int:
@@ -37,14 +112,14 @@ func ScanInt16(v int16, c any) error {
 	case *driver.Value:
 		*cv = driver.Value(v)
 	default:
-		return fmt.Errorf("scan convert from INT16 to %T not supported", c)
+		return scanConvertFallback(v, c, "INT16")
 	}
 	return nil
 }
 
 func ScanInt32(v int32, c any) error {
 	if v == math.MinInt32 {
-		return errors.New("scan NULL INT32")
+		return ScanNull(c)
 	}
 	switch cv := c.(type) {
 	case *int:
@@ -68,14 +143,14 @@ func ScanInt32(v int32, c any) error {
 	case *driver.Value:
 		*cv = driver.Value(v)
 	default:
-		return fmt.Errorf("scan convert from INT32 to %T not supported", c)
+		return scanConvertFallback(v, c, "INT32")
 	}
 	return nil
 }
 
 func ScanInt64(v int64, c any) error {
 	if v == math.MinInt64 {
-		return errors.New("scan NULL INT64")
+		return ScanNull(c)
 	}
 	switch cv := c.(type) {
 	case *int:
@@ -101,7 +176,7 @@ func ScanInt64(v int64, c any) error {
 	case *driver.Value:
 		*cv = driver.Value(v)
 	default:
-		return fmt.Errorf("scan convert from INT64 to %T not supported", c)
+		return scanConvertFallback(v, c, "INT64")
 	}
 	return nil
 }
@@ -117,7 +192,7 @@ func ScanDateTime(v time.Time, c any) error {
 	case *driver.Value:
 		*cv = driver.Value(v)
 	default:
-		return fmt.Errorf("scan convert from INT64 to %T not supported", c)
+		return scanConvertFallback(v, c, "DATETIME")
 	}
 	return nil
 }
@@ -133,7 +208,7 @@ func ScanFloat32(v float32, c any) error {
 	case *driver.Value:
 		*cv = driver.Value(v)
 	default:
-		return fmt.Errorf("scan convert from FLOAT32 to %T not supported", c)
+		return scanConvertFallback(v, c, "FLOAT32")
 	}
 	return nil
 }
@@ -149,15 +224,12 @@ func ScanFloat64(v float64, c any) error {
 	case *driver.Value:
 		*cv = driver.Value(v)
 	default:
-		return fmt.Errorf("scan convert from FLOAT64 to %T not supported", c)
+		return scanConvertFallback(v, c, "FLOAT64")
 	}
 	return nil
 }
 
 func ScanString(v string, c any) error {
-	if len(v) == 0 {
-		return errors.New("scan NULL STRING")
-	}
 	switch cv := c.(type) {
 	case *string:
 		*cv = v
@@ -168,7 +240,7 @@ func ScanString(v string, c any) error {
 	case *driver.Value:
 		*cv = driver.Value(v)
 	default:
-		return fmt.Errorf("scan convert from STRING to %T not supported", c)
+		return scanConvertFallback(v, c, "STRING")
 	}
 	return nil
 }
@@ -182,7 +254,7 @@ func ScanBytes(v []byte, c any) error {
 	case *driver.Value:
 		*cv = driver.Value(v)
 	default:
-		return fmt.Errorf("scan convert from BYTES to %T not supported", c)
+		return scanConvertFallback(v, c, "BYTES")
 	}
 	return nil
 }
@@ -196,7 +268,7 @@ func ScanIP(v net.IP, c any) error {
 	case *driver.Value:
 		*cv = driver.Value(v)
 	default:
-		return fmt.Errorf("scan convert from IPv4 to %T not supported", c)
+		return scanConvertFallback(v, c, "IPv4")
 	}
 	return nil
 }
@@ -0,0 +1,71 @@
+package machrpc_test
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/machbase/neo-client/machrpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanNull(t *testing.T) {
+	var nullInt64 = sql.NullInt64{Int64: 1, Valid: true}
+	require.Nil(t, machrpc.ScanInt64(math.MinInt64, &nullInt64))
+	require.False(t, nullInt64.Valid)
+	require.Nil(t, machrpc.ScanInt64(10, &nullInt64))
+	require.True(t, nullInt64.Valid)
+	require.Equal(t, int64(10), nullInt64.Int64)
+
+	var nullInt16 = sql.NullInt16{Valid: true}
+	require.Nil(t, machrpc.ScanInt16(math.MinInt16, &nullInt16))
+	require.False(t, nullInt16.Valid)
+
+	ptr := new(int)
+	require.Nil(t, machrpc.ScanInt32(math.MinInt32, &ptr))
+	require.Nil(t, ptr)
+
+	var nullString = sql.NullString{String: "x", Valid: true}
+	require.Nil(t, machrpc.ScanNull(&nullString))
+	require.False(t, nullString.Valid)
+
+	var nullTime sql.NullTime
+	require.Nil(t, machrpc.ScanNull(&nullTime))
+	require.False(t, nullTime.Valid)
+
+	var nullFloat sql.NullFloat64
+	require.Nil(t, machrpc.ScanNull(&nullFloat))
+	require.False(t, nullFloat.Valid)
+
+	// not nullable target
+	var i64 int64
+	err := machrpc.ScanInt64(math.MinInt64, &i64)
+	require.NotNil(t, err)
+	require.Equal(t, "scan NULL into *int64 not supported, use sql.Null* or pointer type", err.Error())
+
+	// empty string is not NULL
+	var str = "x"
+	require.Nil(t, machrpc.ScanString("", &str))
+	require.Equal(t, "", str)
+}
+
+func TestScanNullable(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	rows, err := conn.Query(context.TODO(), "select * from example where name = ?", "query1")
+	require.Nil(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var name sql.NullString
+	var ts *time.Time
+	var value sql.NullFloat64
+	require.Nil(t, rows.Scan(&name, &ts, &value))
+	require.Equal(t, sql.NullString{String: "tag", Valid: true}, name)
+	require.NotNil(t, ts)
+	require.Equal(t, int64(1), ts.UnixNano())
+	require.Equal(t, sql.NullFloat64{Float64: 3.14, Valid: true}, value)
+}
@@ -0,0 +1,339 @@
+package machrpc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrSpoolFull is returned when the spool reached SpoolConfig.MaxSize.
+var ErrSpoolFull = errors.New("append spool is full")
+
+// ErrSpoolLocked is returned when the spool directory is used by other Appender,
+// in this process or in other process.
+var ErrSpoolLocked = errors.New("append spool is used by other appender")
+
+// SpoolSync is the fsync policy of the spool.
+type SpoolSync int
+
+const (
+	// SpoolSyncWrite syncs the segment file after every write (default).
+	SpoolSyncWrite SpoolSync = iota
+	// SpoolSyncSegment syncs the segment file when it is rotated or closed.
+	SpoolSyncSegment
+	// SpoolSyncNone leaves syncing to the operating system.
+	SpoolSyncNone
+)
+
+// SpoolConfig is the configuration of the on-disk spool of the Appender.
+type SpoolConfig struct {
+	// Dir is the directory where the segment files are stored.
+	// The records of each table are stored in the sub-directory named after the table.
+	// The sub-directory is locked by the Appender until it is closed,
+	// the other Appenders of the same table and the same Dir fail with ErrSpoolLocked.
+	// Each shard of ParallelAppender has its own sub-directory "shard-N" in the directory of the table.
+	Dir string
+	// SegmentSize is the maximum size of a segment file in bytes, default is 4MB.
+	SegmentSize int64
+	// MaxSize is the maximum total size of the spool in bytes, 0 means unlimited.
+	MaxSize int64
+	// Sync is the fsync policy.
+	Sync SpoolSync
+}
+
+const defaultSpoolSegmentSize = 4 * 1024 * 1024
+
+const spoolSegmentExt = ".spool"
+
+const spoolLockFile = "LOCK"
+
+// maxSpoolRecordSize is the maximum size of an encoded record in the spool.
+// The length of a record that exceeds it is corrupted, the rest of the segment is discarded.
+const maxSpoolRecordSize = 64 * 1024 * 1024
+
+// lockedSpoolDirs are the spool directories that are locked in this process.
+var lockedSpoolDirs = map[string]bool{}
+var lockedSpoolDirsLock sync.Mutex
+
+// spool is a write-ahead log of AppendRecord that consists of segment files.
+// A record is stored as 4 bytes length, 4 bytes crc32 checksum and the protobuf encoded record.
+// The directory is locked by the spool until it is closed, so that other Appenders
+// do not write or replay the same segment files.
+type spool struct {
+	cfg  SpoolConfig
+	dir  string
+	lock *os.File // the lock file of the directory, nil if the spool is closed
+
+	mu        sync.Mutex
+	segments  []uint64 // sequence numbers of the segment files, in order
+	sizes     map[uint64]int64
+	totalSize int64
+	cur       *os.File
+	curSeq    uint64
+}
+
+// openSpool opens the spool of the table, subdir is the optional sub-directory in the directory of the table.
+func openSpool(cfg SpoolConfig, tableName string, subdir string) (*spool, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("spool directory is not specified")
+	}
+	if cfg.SegmentSize <= 0 {
+		cfg.SegmentSize = defaultSpoolSegmentSize
+	}
+	dir, err := filepath.Abs(filepath.Join(cfg.Dir, strings.ToUpper(tableName), subdir))
+	if err != nil {
+		return nil, errors.Wrap(err, "spool")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "spool")
+	}
+	lock, err := lockSpoolDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &spool{cfg: cfg, dir: dir, lock: lock, sizes: map[uint64]int64{}}
+	if err := s.load(); err != nil {
+		s.unlock()
+		return nil, err
+	}
+	return s, nil
+}
+
+// lockSpoolDir takes the exclusive lock of the directory.
+func lockSpoolDir(dir string) (*os.File, error) {
+	lockedSpoolDirsLock.Lock()
+	defer lockedSpoolDirsLock.Unlock()
+	if lockedSpoolDirs[dir] {
+		return nil, errors.Wrap(ErrSpoolLocked, dir)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, spoolLockFile), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "spool")
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, dir)
+	}
+	lockedSpoolDirs[dir] = true
+	return f, nil
+}
+
+// unlock releases the lock of the directory.
+func (s *spool) unlock() {
+	if s.lock == nil {
+		return
+	}
+	lockedSpoolDirsLock.Lock()
+	defer lockedSpoolDirsLock.Unlock()
+	s.lock.Close()
+	s.lock = nil
+	delete(lockedSpoolDirs, s.dir)
+}
+
+// load reads the segment files of the directory.
+func (s *spool) load() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return errors.Wrap(err, "spool")
+	}
+	for _, ent := range entries {
+		name := ent.Name()
+		if ent.IsDir() || !strings.HasSuffix(name, spoolSegmentExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, spoolSegmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		nfo, err := ent.Info()
+		if err != nil {
+			return errors.Wrap(err, "spool")
+		}
+		s.segments = append(s.segments, seq)
+		s.sizes[seq] = nfo.Size()
+		s.totalSize += nfo.Size()
+	}
+	sort.Slice(s.segments, func(i, j int) bool { return s.segments[i] < s.segments[j] })
+	if len(s.segments) > 0 {
+		s.curSeq = s.segments[len(s.segments)-1]
+	}
+	return nil
+}
+
+func (s *spool) segmentPath(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, spoolSegmentExt))
+}
+
+// Empty returns true if there is no spooled record.
+func (s *spool) Empty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.totalSize == 0
+}
+
+// Write appends records to the spool.
+func (s *spool) Write(recs []*AppendRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buf := []byte{}
+	for _, rec := range recs {
+		data, err := proto.Marshal(rec)
+		if err != nil {
+			return errors.Wrap(err, "spool")
+		}
+		if len(data) > maxSpoolRecordSize {
+			return errors.Errorf("spool: record of %d bytes exceeds the limit", len(data))
+		}
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(data)))
+		buf = binary.BigEndian.AppendUint32(buf, crc32.ChecksumIEEE(data))
+		buf = append(buf, data...)
+	}
+	if s.cfg.MaxSize > 0 && s.totalSize+int64(len(buf)) > s.cfg.MaxSize {
+		return ErrSpoolFull
+	}
+	if s.cur == nil || s.sizes[s.curSeq] >= s.cfg.SegmentSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.cur.Write(buf)
+	s.sizes[s.curSeq] += int64(n)
+	s.totalSize += int64(n)
+	if err != nil {
+		return errors.Wrap(err, "spool")
+	}
+	if s.cfg.Sync == SpoolSyncWrite {
+		if err := s.cur.Sync(); err != nil {
+			return errors.Wrap(err, "spool")
+		}
+	}
+	return nil
+}
+
+// rotate closes the current segment and creates a new one.
+func (s *spool) rotate() error {
+	if err := s.closeCurrent(); err != nil {
+		return err
+	}
+	s.curSeq++
+	f, err := os.OpenFile(s.segmentPath(s.curSeq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.Wrap(err, "spool")
+	}
+	s.cur = f
+	s.segments = append(s.segments, s.curSeq)
+	s.sizes[s.curSeq] = 0
+	return nil
+}
+
+func (s *spool) closeCurrent() error {
+	if s.cur == nil {
+		return nil
+	}
+	var err error
+	if s.cfg.Sync != SpoolSyncNone {
+		err = s.cur.Sync()
+	}
+	if closeErr := s.cur.Close(); err == nil {
+		err = closeErr
+	}
+	s.cur = nil
+	return errors.Wrap(err, "spool")
+}
+
+// Replay calls fn with the spooled records in order, at most batchSize records at a time.
+// A segment file is removed after all of its records are passed to fn successfully.
+// If fn fails, Replay stops and the remaining records are replayed by the next call.
+func (s *spool) Replay(batchSize int, fn func(recs []*AppendRecord) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.closeCurrent(); err != nil {
+		return err
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	for len(s.segments) > 0 {
+		seq := s.segments[0]
+		if err := s.replaySegment(seq, batchSize, fn); err != nil {
+			return err
+		}
+		if err := os.Remove(s.segmentPath(seq)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "spool")
+		}
+		s.totalSize -= s.sizes[seq]
+		delete(s.sizes, seq)
+		s.segments = s.segments[1:]
+	}
+	return nil
+}
+
+func (s *spool) replaySegment(seq uint64, batchSize int, fn func(recs []*AppendRecord) error) error {
+	f, err := os.Open(s.segmentPath(seq))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "spool")
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	batch := make([]*AppendRecord, 0, batchSize)
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			// io.EOF or a truncated record that was written partially
+			break
+		}
+		size := binary.BigEndian.Uint32(header[0:4])
+		if size > maxSpoolRecordSize {
+			// the length is corrupted
+			break
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			break
+		}
+		if crc32.ChecksumIEEE(data) != binary.BigEndian.Uint32(header[4:8]) {
+			break
+		}
+		rec := &AppendRecord{}
+		if err := proto.Unmarshal(data, rec); err != nil {
+			break
+		}
+		batch = append(batch, rec)
+		if len(batch) >= batchSize {
+			if err := fn(batch); err != nil {
+				return err
+			}
+			batch = make([]*AppendRecord, 0, batchSize)
+		}
+	}
+	if len(batch) > 0 {
+		return fn(batch)
+	}
+	return nil
+}
+
+// Close closes the current segment file and releases the lock of the directory.
+func (s *spool) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.closeCurrent()
+	s.unlock()
+	return err
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package machrpc
+
+import "os"
+
+// lockFile is a no-op on the platforms without flock,
+// the spool directory is locked only within the process.
+func lockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package machrpc
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes the exclusive lock of the file without blocking,
+// the lock is released when the file is closed.
+func lockFile(f *os.File) error {
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		if err == syscall.EWOULDBLOCK {
+			return ErrSpoolLocked
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+package machrpc
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ScanOption is an option of ScanStruct.
+type ScanOption func(*scanOptions)
+
+type scanOptions struct {
+	strict bool
+}
+
+// ScanStrict makes ScanStruct fail if a column has no matching struct field.
+func ScanStrict() ScanOption {
+	return func(o *scanOptions) {
+		o.strict = true
+	}
+}
+
+// structField is a field of a struct that can be a destination of a column.
+type structField struct {
+	name  string
+	index []int
+}
+
+// structInfo is the list of fields of a struct type, embedded structs are flattened.
+type structInfo struct {
+	fields []*structField
+	byName map[string]*structField // key is lower-case name
+}
+
+var structInfoCache sync.Map // map[reflect.Type]*structInfo
+
+// getStructInfo returns the fields of the struct type t.
+// The name of a field is the value of the `machbase:"name"` tag or the field name.
+// The field that has `machbase:"-"` tag is ignored.
+// If multiple fields have the same name, the shallowest one wins as Go does for promoted fields,
+// a tagged field wins over untagged ones at the same depth, and the name is ignored if it is
+// still ambiguous, as encoding/json does.
+func getStructInfo(t reflect.Type) *structInfo {
+	if v, ok := structInfoCache.Load(t); ok {
+		return v.(*structInfo)
+	}
+	type candidate struct {
+		*structField
+		tagged bool
+	}
+	var candidates []candidate
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag, hasTag := f.Tag.Lookup("machbase")
+			name, _, _ := strings.Cut(tag, ",")
+			if name == "-" {
+				continue
+			}
+			fieldIndex := append(append([]int{}, index...), i)
+			if f.Anonymous && !hasTag {
+				ft := f.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft, fieldIndex)
+					continue
+				}
+			}
+			if !f.IsExported() {
+				continue
+			}
+			tagged := name != ""
+			if !tagged {
+				name = f.Name
+			}
+			candidates = append(candidates, candidate{
+				structField: &structField{name: name, index: fieldIndex},
+				tagged:      tagged,
+			})
+		}
+	}
+	walk(t, nil)
+
+	// dominant returns the field of the key, or nil if it is ambiguous
+	dominant := func(key string) *structField {
+		var found []candidate
+		for _, c := range candidates {
+			if strings.ToLower(c.name) != key {
+				continue
+			}
+			if len(found) > 0 && len(c.index) > len(found[0].index) {
+				continue
+			}
+			if len(found) > 0 && len(c.index) < len(found[0].index) {
+				found = found[:0]
+			}
+			found = append(found, c)
+		}
+		if len(found) > 1 {
+			tagged := found[:0:0]
+			for _, c := range found {
+				if c.tagged {
+					tagged = append(tagged, c)
+				}
+			}
+			found = tagged
+		}
+		if len(found) != 1 {
+			return nil
+		}
+		return found[0].structField
+	}
+	info := &structInfo{byName: map[string]*structField{}}
+	seen := map[string]bool{}
+	for _, c := range candidates {
+		key := strings.ToLower(c.name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if sf := dominant(key); sf != nil {
+			info.byName[key] = sf
+		}
+	}
+	// in the declared order
+	for _, c := range candidates {
+		if info.byName[strings.ToLower(c.name)] == c.structField {
+			info.fields = append(info.fields, c.structField)
+		}
+	}
+	structInfoCache.Store(t, info)
+	return info
+}
+
+// fieldByIndex returns the field of v, allocating embedded struct pointers if necessary.
+// It fails if the nil pointer of an unexported embedded struct has to be allocated.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// scanStruct scans values into the fields of the struct that dst points to.
+// The columns are the names of the values.
+func scanStruct(columns []string, values []any, dst any, opts ...ScanOption) error {
+	so := &scanOptions{}
+	for _, o := range opts {
+		o(so)
+	}
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("scan struct requires non-nil pointer to struct, not %T", dst)
+	}
+	rv = rv.Elem()
+	info := getStructInfo(rv.Type())
+
+	for i, col := range columns {
+		if i >= len(values) {
+			return fmt.Errorf("column %q is out of range %d", col, len(values))
+		}
+		sf, ok := info.byName[strings.ToLower(col)]
+		if !ok {
+			if so.strict {
+				return fmt.Errorf("column %q has no matching field in %T", col, dst)
+			}
+			continue
+		}
+		field, err := fieldByIndex(rv, sf.index)
+		if err != nil {
+			return fmt.Errorf("column %q: %w", col, err)
+		}
+		if err := scanValue(values[i], field.Addr().Interface()); err != nil {
+			return fmt.Errorf("column %q: %w", col, err)
+		}
+	}
+	return nil
+}
+
+// ScanStruct scans the current row into the struct that dst points to.
+// Columns are mapped to the fields by `machbase:"name"` tag or case-insensitive field name.
+//
+//	type Record struct {
+//		Name  string    `machbase:"name"`
+//		Time  time.Time `machbase:"time"`
+//		Value float64   `machbase:"value"`
+//	}
+//
+//	for rows.Next() {
+//		var rec Record
+//		rows.ScanStruct(&rec)
+//	}
+func (rows *Rows) ScanStruct(dst any, opts ...ScanOption) error {
+	if rows.err != nil {
+		return rows.err
+	}
+	if rows.values == nil {
+		return sql.ErrNoRows
+	}
+	if rows.columnNames == nil {
+		names, _, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		rows.columnNames = names
+	}
+	return scanStruct(rows.columnNames, rows.values, dst, opts...)
+}
+
+// ErrNoColumnNames is returned by Row.ScanStruct when the server does not report
+// the names of the columns of the row, use Scan or Query with Rows.ScanStruct instead.
+var ErrNoColumnNames = errors.New("server does not report the column names of the row")
+
+// ScanStruct scans the row into the struct that dst points to.
+// It returns ErrNoColumnNames if the server does not report the columns of the row,
+// the values are not mapped to the fields by the position.
+func (row *Row) ScanStruct(dst any, opts ...ScanOption) error {
+	if row.err != nil {
+		return row.err
+	}
+	if !row.success {
+		return sql.ErrNoRows
+	}
+	if row.columns == nil {
+		return ErrNoColumnNames
+	}
+	return scanStruct(row.columns, row.values, dst, opts...)
+}
+
+// structColumns returns the fields of the struct type t in the order of the columns of the appender.
+// The element is nil if the column has no matching field, it is appended as NULL.
+func (appender *Appender) structColumns(t reflect.Type) ([]*structField, error) {
+	if v, ok := appender.structMaps.Load(t); ok {
+		return v.([]*structField), nil
+	}
+	ac := appender.tableColumns()
+	if ac.columns == nil {
+		return nil, errors.New("server does not provide the columns of the appender")
+	}
+	info := getStructInfo(t)
+	ret := make([]*structField, len(ac.columns))
+	matched := 0
+	for i, c := range ac.columns {
+		if sf, ok := info.byName[strings.ToLower(c.Name)]; ok {
+			ret[i] = sf
+			matched++
+		}
+	}
+	if matched != len(info.fields) {
+		for _, sf := range info.fields {
+			found := false
+			for _, f := range ret {
+				if f == sf {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("field %q of %s has no matching column in table %s", sf.name, t, appender.tableName)
+			}
+		}
+	}
+	appender.structMaps.Store(t, ret)
+	return ret, nil
+}
+
+// AppendStruct appends a new record from the fields of the struct v (or pointer to struct).
+// Fields are mapped to the columns of the table by `machbase:"name"` tag or case-insensitive field name,
+// the columns that have no matching field are appended as NULL.
+// It fails if a field has no matching column.
+//
+//	type Record struct {
+//		Name  string    `machbase:"name"`
+//		Time  time.Time `machbase:"time"`
+//		Value float64   `machbase:"value"`
+//	}
+//
+//	app.AppendStruct(Record{Name: "my_name", Time: time.Now(), Value: 3.14})
+func (appender *Appender) AppendStruct(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return fmt.Errorf("append struct requires non-nil struct, not %T", v)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("append struct requires struct, not %T", v)
+	}
+	fields, err := appender.structColumns(rv.Type())
+	if err != nil {
+		return err
+	}
+	cols := make([]any, len(fields))
+	for i, sf := range fields {
+		if sf == nil {
+			continue
+		}
+		if fv, ok := fieldValue(rv, sf.index); ok {
+			cols[i] = fv.Interface()
+		}
+	}
+	return appender.appendRecord(appender.ctx, cols, true)
+}
+
+// AppendStructs appends the elements of the slice list as AppendStruct does.
+// It stops at the first error.
+func (appender *Appender) AppendStructs(list any) error {
+	rv := reflect.ValueOf(list)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("append structs requires slice, not %T", list)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := appender.AppendStruct(rv.Index(i).Interface()); err != nil {
+			return errors.Wrapf(err, "append structs[%d]", i)
+		}
+	}
+	return nil
+}
+
+// fieldValue returns the field of v, it returns false if an embedded struct pointer is nil.
+func fieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
@@ -0,0 +1,119 @@
+package machrpc
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// timeConverter converts the values of the datetime columns into nanoseconds
+// according to the timeformat of the Appender.
+type timeConverter struct {
+	unit   int64  // nanoseconds of the epoch unit, 0 if layout is used
+	layout string // layout of time.Parse
+	loc    *time.Location
+}
+
+func newTimeConverter(format string, loc *time.Location) *timeConverter {
+	tc := &timeConverter{loc: loc}
+	if tc.loc == nil {
+		tc.loc = time.UTC
+	}
+	switch format {
+	case "s":
+		tc.unit = int64(time.Second)
+	case "ms":
+		tc.unit = int64(time.Millisecond)
+	case "us":
+		tc.unit = int64(time.Microsecond)
+	case "ns", "":
+		tc.unit = int64(time.Nanosecond)
+	case "RFC3339":
+		tc.layout = time.RFC3339
+	case "RFC3339Nano":
+		tc.layout = time.RFC3339Nano
+	default:
+		tc.layout = format
+	}
+	return tc
+}
+
+// convert returns the datum of the time in nanoseconds.
+func (tc *timeConverter) convert(d *AppendDatum) (*AppendDatum, error) {
+	var epoch int64
+	switch v := d.Value.(type) {
+	case *AppendDatum_VTime, *AppendDatum_VNull:
+		return d, nil
+	case *AppendDatum_VInt32:
+		epoch = int64(v.VInt32)
+	case *AppendDatum_VInt64:
+		epoch = v.VInt64
+	case *AppendDatum_VUint32:
+		epoch = int64(v.VUint32)
+	case *AppendDatum_VUint64:
+		if v.VUint64 > math.MaxInt64 {
+			return nil, fmt.Errorf("time %d is out of range", v.VUint64)
+		}
+		epoch = int64(v.VUint64)
+	case *AppendDatum_VDouble:
+		if tc.unit == 0 {
+			return nil, fmt.Errorf("number %v for time layout %q", v.VDouble, tc.layout)
+		}
+		return tc.fromFloat(v.VDouble)
+	case *AppendDatum_VFloat:
+		if tc.unit == 0 {
+			return nil, fmt.Errorf("number %v for time layout %q", v.VFloat, tc.layout)
+		}
+		return tc.fromFloat(float64(v.VFloat))
+	case *AppendDatum_VString:
+		if tc.unit == 0 {
+			ts, err := time.ParseInLocation(tc.layout, v.VString, tc.loc)
+			if err != nil {
+				return nil, err
+			}
+			ns, err := timeNanos(ts)
+			if err != nil {
+				return nil, err
+			}
+			return &AppendDatum{Value: &AppendDatum_VTime{VTime: ns}}, nil
+		}
+		n, err := strconv.ParseInt(v.VString, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid epoch time %q", v.VString)
+		}
+		epoch = n
+	default:
+		return nil, fmt.Errorf("%T can not be time", v)
+	}
+	if tc.unit == 0 {
+		return nil, fmt.Errorf("number %d for time layout %q", epoch, tc.layout)
+	}
+	if epoch > math.MaxInt64/tc.unit || epoch < math.MinInt64/tc.unit {
+		return nil, fmt.Errorf("time %d is out of range", epoch)
+	}
+	return &AppendDatum{Value: &AppendDatum_VTime{VTime: epoch * tc.unit}}, nil
+}
+
+func (tc *timeConverter) fromFloat(f float64) (*AppendDatum, error) {
+	ns := f * float64(tc.unit)
+	if math.IsNaN(ns) || ns > math.MaxInt64 || ns < math.MinInt64 {
+		return nil, fmt.Errorf("time %v is out of range", f)
+	}
+	return &AppendDatum{Value: &AppendDatum_VTime{VTime: int64(ns)}}, nil
+}
+
+// convertTimes converts the values of the datetime columns of the tuple in place.
+func (appender *Appender) convertTimes(ac *appenderColumns, tuple []*AppendDatum) error {
+	for _, idx := range ac.timeColumns {
+		if idx >= len(tuple) {
+			continue
+		}
+		d, err := appender.timeConv.convert(tuple[idx])
+		if err != nil {
+			return fmt.Errorf("convert column %q: %w", ac.names[idx], err)
+		}
+		tuple[idx] = d
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package machrpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+)
+
+// TlsConfig is the TLS configuration of the Client.
+//
+// The server certificate is verified by the CA certificate of ServerCert or ServerCertPEM,
+// or by the system roots if both are empty.
+// The client certificate is optional, it is required only if the server authenticates the clients.
+//
+//	cli, _ := machrpc.NewClient(&machrpc.Config{
+//		ServerAddr: "tcp://127.0.0.1:5655",
+//		Tls: &machrpc.TlsConfig{
+//			ServerCert: "/path/to/ca_cert.pem",
+//			ServerName: "machbase-neo",
+//		},
+//	})
+//
+// The certificates of the file paths are reloaded automatically
+// when the files are changed on disk, the new connections use the new certificates.
+type TlsConfig struct {
+	ClientCert string // path of the client certificate file
+	ClientKey  string // path of the client private key file
+	ServerCert string // path of the CA certificate file that verifies the server
+
+	// ClientCertPEM, ClientKeyPEM and ServerCertPEM are the PEM encoded contents
+	// that are used instead of the files.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	ServerCertPEM []byte
+
+	// ServerName overrides the name that verifies the server certificate and is sent as SNI,
+	// the host of the server address is used if it is empty.
+	ServerName string
+
+	// InsecureSkipVerify disables the verification of the server certificate.
+	// It makes the connection vulnerable to man-in-the-middle attacks, use it only for testing.
+	InsecureSkipVerify bool
+
+	// Config is the base of the TLS configuration, it is cloned and the other fields are applied to it.
+	Config *tls.Config
+}
+
+// enabled returns true if any of the fields is set.
+func (tc *TlsConfig) enabled() bool {
+	if tc == nil {
+		return false
+	}
+	return tc.Config != nil || tc.ServerName != "" || tc.InsecureSkipVerify ||
+		tc.ServerCert != "" || len(tc.ServerCertPEM) > 0 ||
+		tc.ClientCert != "" || tc.ClientKey != "" ||
+		len(tc.ClientCertPEM) > 0 || len(tc.ClientKeyPEM) > 0
+}
+
+// credentials returns the transport credentials that build the tls.Config for every handshake,
+// so that the rotated certificate files take effect without restarting the client.
+func (tc *TlsConfig) credentials() (credentials.TransportCredentials, error) {
+	if (tc.ClientCert == "") != (tc.ClientKey == "") {
+		return nil, errors.New("tls: both ClientCert and ClientKey are required")
+	}
+	if (len(tc.ClientCertPEM) == 0) != (len(tc.ClientKeyPEM) == 0) {
+		return nil, errors.New("tls: both ClientCertPEM and ClientKeyPEM are required")
+	}
+	conf := *tc // the caller may modify tc after NewClient
+	files := &certFiles{certPath: conf.ClientCert, keyPath: conf.ClientKey, caPath: conf.ServerCert}
+	build := func() (*tls.Config, error) {
+		return conf.build(files)
+	}
+	cfg, err := build()
+	if err != nil {
+		return nil, err
+	}
+	return &reloadingCredentials{TransportCredentials: credentials.NewTLS(cfg), build: build}, nil
+}
+
+func (tc *TlsConfig) build(files *certFiles) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if tc.Config != nil {
+		cfg = tc.Config.Clone()
+	}
+	if tc.ServerName != "" {
+		cfg.ServerName = tc.ServerName
+	}
+	if tc.InsecureSkipVerify {
+		cfg.InsecureSkipVerify = true
+	}
+
+	if len(tc.ServerCertPEM) > 0 {
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(tc.ServerCertPEM) {
+			return nil, errors.New("tls: invalid ServerCertPEM")
+		}
+		cfg.RootCAs = roots
+	} else if files.caPath != "" {
+		roots, err := files.rootCAs()
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = roots
+	}
+
+	if len(tc.ClientCertPEM) > 0 {
+		cert, err := tls.X509KeyPair(tc.ClientCertPEM, tc.ClientKeyPEM)
+		if err != nil {
+			return nil, errors.Wrap(err, "tls: invalid ClientCertPEM")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	} else if files.certPath != "" {
+		cert, err := files.keyPair()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{*cert}
+	}
+	return cfg, nil
+}
+
+// reloadingCredentials performs the client handshake with the tls.Config that is built for each connection.
+type reloadingCredentials struct {
+	credentials.TransportCredentials
+	build func() (*tls.Config, error)
+}
+
+func (c *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	cfg, err := c.build()
+	if err != nil {
+		return nil, nil, err
+	}
+	return credentials.NewTLS(cfg).ClientHandshake(ctx, authority, rawConn)
+}
+
+func (c *reloadingCredentials) Clone() credentials.TransportCredentials {
+	return &reloadingCredentials{TransportCredentials: c.TransportCredentials.Clone(), build: c.build}
+}
+
+// certFiles caches the certificates of the files and reloads them when the files are changed.
+type certFiles struct {
+	certPath string
+	keyPath  string
+	caPath   string
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	certStamp string
+	roots     *x509.CertPool
+	rootStamp string
+}
+
+// fileStamp returns a string that changes when any of the files is modified.
+func fileStamp(paths ...string) (string, error) {
+	stamp := ""
+	for _, path := range paths {
+		nfo, err := os.Stat(path)
+		if err != nil {
+			return "", errors.Wrap(err, "tls")
+		}
+		stamp += fmt.Sprintf("%s:%d:%d;", path, nfo.Size(), nfo.ModTime().UnixNano())
+	}
+	return stamp, nil
+}
+
+func (f *certFiles) keyPair() (*tls.Certificate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stamp, err := fileStamp(f.certPath, f.keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if f.cert != nil && stamp == f.certStamp {
+		return f.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(f.certPath, f.keyPath)
+	if err != nil {
+		// the files may be in the middle of rotation, they are loaded again by the next handshake
+		return nil, errors.Wrap(err, "tls")
+	}
+	f.cert, f.certStamp = &cert, stamp
+	return f.cert, nil
+}
+
+func (f *certFiles) rootCAs() (*x509.CertPool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stamp, err := fileStamp(f.caPath)
+	if err != nil {
+		return nil, err
+	}
+	if f.roots != nil && stamp == f.rootStamp {
+		return f.roots, nil
+	}
+	pem, err := os.ReadFile(f.caPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "tls")
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, errors.Errorf("tls: invalid server certificate %q", f.caPath)
+	}
+	f.roots, f.rootStamp = roots, stamp
+	return f.roots, nil
+}
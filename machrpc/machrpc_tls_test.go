@@ -0,0 +1,183 @@
+package machrpc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/machbase/neo-client/machrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.Nil(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns the PEM encoded certificate and private key that are signed by the ca.
+func (ca *testCA) issue(t *testing.T, name string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// startTLSServer serves the mock server with TLS, it returns the address.
+func startTLSServer(t *testing.T, cfg *tls.Config) string {
+	t.Helper()
+	return startServer(t, grpc.Creds(credentials.NewTLS(cfg)))
+}
+
+// tryConnect returns the error of Connect, the connection is closed if it succeeded.
+func tryConnect(t *testing.T, addr string, tc *machrpc.TlsConfig) error {
+	t.Helper()
+	cli, err := machrpc.NewClient(&machrpc.Config{ServerAddr: addr, Tls: tc})
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	ctx, cancel := context.WithTimeout(context.TODO(), 3*time.Second)
+	defer cancel()
+	conn, err := cli.Connect(ctx, machrpc.WithPassword("sys", "manager"))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func TestTLS(t *testing.T) {
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, "neo.test", x509.ExtKeyUsageServerAuth)
+	serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.Nil(t, err)
+	addr := startTLSServer(t, &tls.Config{Certificates: []tls.Certificate{serverCert}})
+
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	require.Nil(t, os.WriteFile(caPath, ca.pem, 0600))
+
+	// server-only TLS with the CA of PEM bytes
+	require.Nil(t, tryConnect(t, addr, &machrpc.TlsConfig{ServerCertPEM: ca.pem, ServerName: "neo.test"}))
+	// the CA of the file
+	require.Nil(t, tryConnect(t, addr, &machrpc.TlsConfig{ServerCert: caPath, ServerName: "neo.test"}))
+	// *tls.Config
+	require.Nil(t, tryConnect(t, addr, &machrpc.TlsConfig{Config: &tls.Config{RootCAs: ca.pool(), ServerName: "neo.test"}}))
+
+	// the server certificate is verified by default
+	require.ErrorIs(t, tryConnect(t, addr, &machrpc.TlsConfig{ServerCertPEM: ca.pem}), machrpc.ErrUnavailable)
+	require.ErrorIs(t, tryConnect(t, addr, &machrpc.TlsConfig{ServerName: "neo.test"}), machrpc.ErrUnavailable)
+	require.ErrorIs(t, tryConnect(t, addr, &machrpc.TlsConfig{ServerCertPEM: ca.pem, ServerName: "other.test"}), machrpc.ErrUnavailable)
+	// unless it is disabled explicitly
+	require.Nil(t, tryConnect(t, addr, &machrpc.TlsConfig{InsecureSkipVerify: true}))
+
+	// invalid configurations
+	require.NotNil(t, tryConnect(t, addr, &machrpc.TlsConfig{ServerCertPEM: []byte("invalid")}))
+	require.NotNil(t, tryConnect(t, addr, &machrpc.TlsConfig{ClientCert: caPath}))
+}
+
+func TestTLSClientCert(t *testing.T) {
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, "neo.test", x509.ExtKeyUsageServerAuth)
+	serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.Nil(t, err)
+	addr := startTLSServer(t, &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    ca.pool(),
+	})
+
+	clientCert, clientKey := ca.issue(t, "client", x509.ExtKeyUsageClientAuth)
+	require.Nil(t, tryConnect(t, addr, &machrpc.TlsConfig{
+		ServerCertPEM: ca.pem,
+		ServerName:    "neo.test",
+		ClientCertPEM: clientCert,
+		ClientKeyPEM:  clientKey,
+	}))
+	require.NotNil(t, tryConnect(t, addr, &machrpc.TlsConfig{ServerCertPEM: ca.pem, ServerName: "neo.test"}))
+
+	// the client certificate files are reloaded when they are rotated
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	otherCert, otherKey := newTestCA(t).issue(t, "client", x509.ExtKeyUsageClientAuth)
+	require.Nil(t, os.WriteFile(certPath, otherCert, 0600))
+	require.Nil(t, os.WriteFile(keyPath, otherKey, 0600))
+
+	cli, err := machrpc.NewClient(&machrpc.Config{
+		ServerAddr: addr,
+		Tls: &machrpc.TlsConfig{
+			ServerCertPEM: ca.pem,
+			ServerName:    "neo.test",
+			ClientCert:    certPath,
+			ClientKey:     keyPath,
+		},
+	})
+	require.Nil(t, err)
+	defer cli.Close()
+	connect := func() error {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+		conn, err := cli.Connect(ctx, machrpc.WithPassword("sys", "manager"))
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	require.NotNil(t, connect())
+
+	require.Nil(t, os.WriteFile(certPath, clientCert, 0600))
+	require.Nil(t, os.WriteFile(keyPath, clientKey, 0600))
+	require.Eventually(t, func() bool { return connect() == nil }, 10*time.Second, 100*time.Millisecond)
+}
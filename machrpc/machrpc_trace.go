@@ -0,0 +1,217 @@
+package machrpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TraceEvent describes a statement or an append flush that is passed to the Hooks.
+type TraceEvent struct {
+	Op           string        // "Exec", "Query", "QueryRow" or "Append"
+	SQL          string        // SQL text of the statement, empty for Append
+	Params       []any         // params of the statement, rewritten by Config.RedactParams
+	Table        string        // table name of Append
+	Records      int           // number of records of the Append flush
+	Started      time.Time     // time the statement or the flush started
+	Elapsed      time.Duration // zero for OnQueryStart
+	RowsAffected int64
+	ServerElapse string // elapse that the server reported, empty if the call did not reach the server
+	Err          error  // nil if it succeeded
+}
+
+// Hooks receives the events of the statements and the append flushes of the Client.
+//
+// The callbacks are called synchronously by the goroutine of the call,
+// the append flushes may be reported by the background goroutine of the Appender.
+// They should return quickly and should not call the Client.
+// Embed NoopHooks to implement some of the callbacks.
+type Hooks interface {
+	// OnQueryStart is called before Exec, Query and QueryRow send the statement.
+	OnQueryStart(ctx context.Context, evt TraceEvent)
+	// OnQueryEnd is called when Query and QueryRow returns.
+	// The elapsed time of Query does not include fetching the rows.
+	OnQueryEnd(ctx context.Context, evt TraceEvent)
+	// OnExec is called when Exec returns.
+	OnExec(ctx context.Context, evt TraceEvent)
+	// OnAppendFlush is called when an Appender sent the buffered records or failed to send.
+	OnAppendFlush(ctx context.Context, evt TraceEvent)
+	// OnError is called when any of the above failed, after OnQueryEnd, OnExec or OnAppendFlush.
+	OnError(ctx context.Context, evt TraceEvent)
+}
+
+// NoopHooks implements Hooks with the callbacks that do nothing.
+//
+//	type myHooks struct {
+//		machrpc.NoopHooks
+//	}
+//
+//	func (h *myHooks) OnError(ctx context.Context, evt machrpc.TraceEvent) {
+//		metrics.Errors.Inc()
+//	}
+type NoopHooks struct{}
+
+var _ Hooks = NoopHooks{}
+
+func (NoopHooks) OnQueryStart(ctx context.Context, evt TraceEvent)  {}
+func (NoopHooks) OnQueryEnd(ctx context.Context, evt TraceEvent)    {}
+func (NoopHooks) OnExec(ctx context.Context, evt TraceEvent)        {}
+func (NoopHooks) OnAppendFlush(ctx context.Context, evt TraceEvent) {}
+func (NoopHooks) OnError(ctx context.Context, evt TraceEvent)       {}
+
+// RedactAll replaces every param with "?", it can be used as Config.RedactParams.
+func RedactAll(sqlText string, params []any) []any {
+	ret := make([]any, len(params))
+	for i := range ret {
+		ret[i] = "?"
+	}
+	return ret
+}
+
+// attrs returns the attributes of the event for slog.
+func (evt TraceEvent) attrs() []any {
+	ret := []any{slog.String("op", evt.Op)}
+	if evt.Op == "Append" {
+		ret = append(ret, slog.String("table", evt.Table), slog.Int("records", evt.Records))
+	} else {
+		ret = append(ret, slog.String("sql", evt.SQL))
+		if len(evt.Params) > 0 {
+			ret = append(ret, slog.Any("params", evt.Params))
+		}
+		ret = append(ret, slog.Int64("rows_affected", evt.RowsAffected))
+	}
+	ret = append(ret, slog.Duration("elapsed", evt.Elapsed))
+	if evt.ServerElapse != "" {
+		ret = append(ret, slog.String("server_elapse", evt.ServerElapse))
+	}
+	if evt.Err != nil {
+		ret = append(ret, slog.String("error", evt.Err.Error()))
+	}
+	return ret
+}
+
+// logHooks logs the statements and the flushes at the debug level and the failures at the error level.
+// It is installed by Config.Logger.
+type logHooks struct {
+	NoopHooks
+	logger *slog.Logger
+}
+
+func (h *logHooks) OnQueryEnd(ctx context.Context, evt TraceEvent) {
+	h.logger.DebugContext(ctx, "machrpc query", evt.attrs()...)
+}
+
+func (h *logHooks) OnExec(ctx context.Context, evt TraceEvent) {
+	h.logger.DebugContext(ctx, "machrpc exec", evt.attrs()...)
+}
+
+func (h *logHooks) OnAppendFlush(ctx context.Context, evt TraceEvent) {
+	h.logger.DebugContext(ctx, "machrpc append flush", evt.attrs()...)
+}
+
+func (h *logHooks) OnError(ctx context.Context, evt TraceEvent) {
+	h.logger.ErrorContext(ctx, "machrpc "+evt.Op+" failed", evt.attrs()...)
+}
+
+// NewSlowQueryLogger returns the Hooks that logs the statements and the append flushes
+// that take the threshold or longer at the warning level.
+// slog.Default() is used if logger is nil.
+//
+//	cli, _ := machrpc.NewClient(&machrpc.Config{
+//		ServerAddr: "tcp://127.0.0.1:5655",
+//		Hooks:      []machrpc.Hooks{machrpc.NewSlowQueryLogger(logger, time.Second)},
+//	})
+func NewSlowQueryLogger(logger *slog.Logger, threshold time.Duration) Hooks {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &slowQueryLogger{logger: logger, threshold: threshold}
+}
+
+type slowQueryLogger struct {
+	NoopHooks
+	logger    *slog.Logger
+	threshold time.Duration
+}
+
+func (h *slowQueryLogger) log(ctx context.Context, evt TraceEvent) {
+	if evt.Elapsed < h.threshold {
+		return
+	}
+	h.logger.WarnContext(ctx, "machrpc slow "+evt.Op, append(evt.attrs(), slog.Duration("threshold", h.threshold))...)
+}
+
+func (h *slowQueryLogger) OnQueryEnd(ctx context.Context, evt TraceEvent)    { h.log(ctx, evt) }
+func (h *slowQueryLogger) OnExec(ctx context.Context, evt TraceEvent)        { h.log(ctx, evt) }
+func (h *slowQueryLogger) OnAppendFlush(ctx context.Context, evt TraceEvent) { h.log(ctx, evt) }
+
+// traceStart calls OnQueryStart of the hooks, it returns nil if the client has no hooks.
+func (client *Client) traceStart(ctx context.Context, op string, sqlText string, params []any) *TraceEvent {
+	if len(client.hooks) == 0 {
+		return nil
+	}
+	if client.redactParams != nil && len(params) > 0 {
+		params = client.redactParams(sqlText, params)
+	}
+	evt := &TraceEvent{Op: op, SQL: sqlText, Params: params, Started: time.Now()}
+	for _, h := range client.hooks {
+		h.OnQueryStart(ctx, *evt)
+	}
+	return evt
+}
+
+// traceEnd calls OnExec or OnQueryEnd of the hooks with the result of the statement,
+// and OnError if err is not nil.
+func (client *Client) traceEnd(ctx context.Context, evt *TraceEvent, rowsAffected int64, serverElapse string, err error) {
+	if evt == nil {
+		return
+	}
+	evt.Elapsed = time.Since(evt.Started)
+	evt.RowsAffected, evt.ServerElapse, evt.Err = rowsAffected, serverElapse, err
+	for _, h := range client.hooks {
+		if evt.Op == "Exec" {
+			h.OnExec(ctx, *evt)
+		} else {
+			h.OnQueryEnd(ctx, *evt)
+		}
+	}
+	if err != nil {
+		for _, h := range client.hooks {
+			h.OnError(ctx, *evt)
+		}
+	}
+}
+
+// serverElapse returns the elapsed time that the server reported with the failure.
+func serverElapse(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Elapse
+	}
+	return ""
+}
+
+// traceFlush calls OnAppendFlush of the hooks, and OnError if the flush failed.
+func (client *Client) traceFlush(ctx context.Context, table string, result FlushResult) {
+	if len(client.hooks) == 0 {
+		return
+	}
+	evt := TraceEvent{
+		Op:      "Append",
+		Table:   table,
+		Records: result.Records,
+		Started: time.Now().Add(-result.Elapsed),
+		Elapsed: result.Elapsed,
+		Err:     result.Err,
+	}
+	for _, h := range client.hooks {
+		h.OnAppendFlush(ctx, evt)
+	}
+	if evt.Err != nil {
+		for _, h := range client.hooks {
+			h.OnError(ctx, evt)
+		}
+	}
+}
@@ -1,8 +1,14 @@
 package machrpc
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"math"
 	"net"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -11,85 +17,241 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+var (
+	codecs    sync.Map // map[reflect.Type]func(any) (any, error)
+	hasCodecs atomic.Bool
+)
+
+// RegisterCodec registers the function that converts the values of type T
+// into one of the supported types, before they are sent as query params or append columns.
+// The codec takes precedence over the built-in conversion of T.
+//
+//	machrpc.RegisterCodec(func(v uuid.UUID) (any, error) {
+//		return v.String(), nil
+//	})
+func RegisterCodec[T any](fn func(T) (any, error)) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	codecs.Store(typ, func(v any) (any, error) { return fn(v.(T)) })
+	hasCodecs.Store(true)
+}
+
+// maxConvertDepth limits the nested conversions of codecs and driver.Valuer.
+const maxConvertDepth = 8
+
+// convertDatum converts the value into AppendDatum to append,
+// the time is sent in nanoseconds since the epoch.
+func convertDatum(p any, depth int) (*AppendDatum, error) {
+	v, err := basicValue(p, depth)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := v.(time.Time); ok {
+		ns, err := timeNanos(t)
+		if err != nil {
+			return nil, err
+		}
+		return &AppendDatum{Value: &AppendDatum_VTime{VTime: ns}}, nil
+	}
+	return basicDatum(v), nil
+}
+
+// convertParam converts the value into the query param,
+// the time is sent as the timestamp that has no range limit of the nanoseconds.
+func convertParam(p any) (*anypb.Any, error) {
+	v, err := basicValue(p, 0)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := v.(time.Time); ok {
+		return anypb.New(timestamppb.New(t))
+	}
+	return datumToPb(basicDatum(v))
+}
+
+// minTimeNanos and maxTimeNanos are the range of the time that int64 nanoseconds can represent.
+var (
+	minTimeNanos = time.Unix(0, math.MinInt64)
+	maxTimeNanos = time.Unix(0, math.MaxInt64)
+)
+
+// timeNanos returns the nanoseconds since the epoch of the time,
+// it returns error if the time is out of the range instead of wrapping around.
+func timeNanos(t time.Time) (int64, error) {
+	if t.Before(minTimeNanos) || t.After(maxTimeNanos) {
+		return 0, fmt.Errorf("time %s is out of range", t.Format(time.RFC3339))
+	}
+	return t.UnixNano(), nil
+}
+
+// basicValue resolves the codecs, driver.Valuer, pointers and named types of the value.
+// It returns nil or one of int32, int64, uint32, uint64, float32, float64, string, bool,
+// []byte, net.IP and time.Time, it is the common conversion of query params and append columns.
+func basicValue(p any, depth int) (any, error) {
+	if p == nil {
+		return nil, nil
+	}
+	if depth > maxConvertDepth {
+		return nil, fmt.Errorf("too deep conversion of type %T", p)
+	}
+	if hasCodecs.Load() {
+		if codec, ok := codecs.Load(reflect.TypeOf(p)); ok {
+			v, err := codec.(func(any) (any, error))(p)
+			if err != nil {
+				return nil, err
+			}
+			return basicValue(v, depth+1)
+		}
+	}
+	switch v := p.(type) {
+	case int:
+		// int and uint are sent in 64 bits not to truncate the values
+		return int64(v), nil
+	case int8:
+		return int32(v), nil
+	case int16:
+		return int32(v), nil
+	case int32, int64, uint32, uint64, float32, float64, string, bool, []byte, net.IP, time.Time:
+		return v, nil
+	case uint:
+		return uint64(v), nil
+	case uint8:
+		return uint32(v), nil
+	case uint16:
+		return uint32(v), nil
+	case json.RawMessage:
+		return string(v), nil
+	case time.Duration:
+		return int64(v), nil
+	case map[string]any:
+		return jsonString(v)
+	case driver.Valuer:
+		rv := reflect.ValueOf(p)
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return nil, nil
+		}
+		val, err := v.Value()
+		if err != nil {
+			return nil, err
+		}
+		return basicValue(val, depth+1)
+	}
+
+	// pointers and named types of the basic kinds
+	rv := reflect.ValueOf(p)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return basicValue(rv.Elem().Interface(), depth+1)
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		// the same width as the underlying type
+		return int32(rv.Int()), nil
+	case reflect.Int, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return uint32(rv.Uint()), nil
+	case reflect.Uint, reflect.Uint64:
+		return rv.Uint(), nil
+	case reflect.Float32:
+		return float32(rv.Float()), nil
+	case reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Bytes(), nil
+		}
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			return jsonString(p)
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %T", p)
+}
+
+// basicDatum returns the datum of the value that basicValue returned, except time.Time.
+func basicDatum(v any) *AppendDatum {
+	switch v := v.(type) {
+	case int32:
+		return &AppendDatum{Value: &AppendDatum_VInt32{VInt32: v}}
+	case int64:
+		return &AppendDatum{Value: &AppendDatum_VInt64{VInt64: v}}
+	case uint32:
+		return &AppendDatum{Value: &AppendDatum_VUint32{VUint32: v}}
+	case uint64:
+		return &AppendDatum{Value: &AppendDatum_VUint64{VUint64: v}}
+	case float32:
+		return &AppendDatum{Value: &AppendDatum_VFloat{VFloat: v}}
+	case float64:
+		return &AppendDatum{Value: &AppendDatum_VDouble{VDouble: v}}
+	case string:
+		return &AppendDatum{Value: &AppendDatum_VString{VString: v}}
+	case bool:
+		return &AppendDatum{Value: &AppendDatum_VBool{VBool: v}}
+	case []byte:
+		return &AppendDatum{Value: &AppendDatum_VBytes{VBytes: v}}
+	case net.IP:
+		return &AppendDatum{Value: &AppendDatum_VIp{VIp: v.String()}}
+	}
+	return &AppendDatum{Value: &AppendDatum_VNull{VNull: true}}
+}
+
+// jsonString encodes the value as JSON string for the JSON column.
+func jsonString(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// datumToPb converts the AppendDatum into the query param.
+func datumToPb(d *AppendDatum) (*anypb.Any, error) {
+	switch v := d.Value.(type) {
+	case *AppendDatum_VInt32:
+		return anypb.New(wrapperspb.Int32(v.VInt32))
+	case *AppendDatum_VUint32:
+		return anypb.New(wrapperspb.UInt32(v.VUint32))
+	case *AppendDatum_VInt64:
+		return anypb.New(wrapperspb.Int64(v.VInt64))
+	case *AppendDatum_VUint64:
+		return anypb.New(wrapperspb.UInt64(v.VUint64))
+	case *AppendDatum_VFloat:
+		return anypb.New(wrapperspb.Float(v.VFloat))
+	case *AppendDatum_VDouble:
+		return anypb.New(wrapperspb.Double(v.VDouble))
+	case *AppendDatum_VString:
+		return anypb.New(wrapperspb.String(v.VString))
+	case *AppendDatum_VBool:
+		return anypb.New(wrapperspb.Bool(v.VBool))
+	case *AppendDatum_VBytes:
+		return anypb.New(wrapperspb.Bytes(v.VBytes))
+	case *AppendDatum_VIp:
+		return anypb.New(wrapperspb.String(v.VIp))
+	case *AppendDatum_VTime:
+		return anypb.New(timestamppb.New(time.Unix(0, v.VTime)))
+	case *AppendDatum_VNull:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unhandled datum type %T", v)
+	}
+}
+
+// ConvertAnyToPb converts the query params into protobuf values.
+// In addition to the basic types, pointers, named types of the basic kinds, time.Duration,
+// json.RawMessage, map[string]any (as JSON), driver.Valuer and the types of RegisterCodec are supported.
 func ConvertAnyToPb(params []any) ([]*anypb.Any, error) {
 	pbparams := make([]*anypb.Any, len(params))
-	var err error
 	for i, p := range params {
-		if p == nil {
-			pbparams[i] = nil
-			continue
-		}
-		switch v := p.(type) {
-		case *int:
-			pbparams[i], err = anypb.New(wrapperspb.Int32(int32(*v)))
-		case int:
-			pbparams[i], err = anypb.New(wrapperspb.Int32(int32(v)))
-		case *uint:
-			pbparams[i], err = anypb.New(wrapperspb.UInt32(uint32(*v)))
-		case uint:
-			pbparams[i], err = anypb.New(wrapperspb.UInt32(uint32(v)))
-		case *int8:
-			pbparams[i], err = anypb.New(wrapperspb.Int32(int32(*v)))
-		case int8:
-			pbparams[i], err = anypb.New(wrapperspb.Int32(int32(v)))
-		case *uint8:
-			pbparams[i], err = anypb.New(wrapperspb.UInt32(uint32(*v)))
-		case uint8:
-			pbparams[i], err = anypb.New(wrapperspb.UInt32(uint32(v)))
-		case *int16:
-			pbparams[i], err = anypb.New(wrapperspb.Int32(int32(*v)))
-		case int16:
-			pbparams[i], err = anypb.New(wrapperspb.Int32(int32(v)))
-		case *uint16:
-			pbparams[i], err = anypb.New(wrapperspb.UInt32(uint32(*v)))
-		case uint16:
-			pbparams[i], err = anypb.New(wrapperspb.UInt32(uint32(v)))
-		case *int32:
-			pbparams[i], err = anypb.New(wrapperspb.Int32(*v))
-		case int32:
-			pbparams[i], err = anypb.New(wrapperspb.Int32(v))
-		case *uint32:
-			pbparams[i], err = anypb.New(wrapperspb.UInt32(*v))
-		case uint32:
-			pbparams[i], err = anypb.New(wrapperspb.UInt32(v))
-		case *int64:
-			pbparams[i], err = anypb.New(wrapperspb.Int64(*v))
-		case int64:
-			pbparams[i], err = anypb.New(wrapperspb.Int64(v))
-		case *uint64:
-			pbparams[i], err = anypb.New(wrapperspb.UInt64(*v))
-		case uint64:
-			pbparams[i], err = anypb.New(wrapperspb.UInt64(v))
-		case *float32:
-			pbparams[i], err = anypb.New(wrapperspb.Float(*v))
-		case float32:
-			pbparams[i], err = anypb.New(wrapperspb.Float(v))
-		case *float64:
-			pbparams[i], err = anypb.New(wrapperspb.Double(*v))
-		case float64:
-			pbparams[i], err = anypb.New(wrapperspb.Double(v))
-		case *string:
-			pbparams[i], err = anypb.New(wrapperspb.String(*v))
-		case string:
-			pbparams[i], err = anypb.New(wrapperspb.String(v))
-		case *[]byte:
-			pbparams[i], err = anypb.New(wrapperspb.Bytes(*v))
-		case []byte:
-			pbparams[i], err = anypb.New(wrapperspb.Bytes(v))
-		case *net.IP:
-			pbparams[i], err = anypb.New(wrapperspb.String(v.String()))
-		case net.IP:
-			pbparams[i], err = anypb.New(wrapperspb.String(v.String()))
-		case *time.Time:
-			pbparams[i], err = anypb.New(timestamppb.New(*v))
-		case time.Time:
-			pbparams[i], err = anypb.New(timestamppb.New(v))
-		default:
-			return nil, fmt.Errorf("unsupported params[%d] type %T", i, p)
-		}
+		pb, err := convertParam(p)
 		if err != nil {
 			return nil, errors.Wrapf(err, "convert params[%d]", i)
 		}
+		pbparams[i] = pb
 	}
 	return pbparams, nil
 }
@@ -149,63 +311,24 @@ func ConvertPbToAny(pbvals []*anypb.Any) []any {
 	return vals
 }
 
+// ConvertAnyToPbTuple converts the columns of a record to append.
+// It supports the same types as ConvertAnyToPb.
 func ConvertAnyToPbTuple(params []any) ([]*AppendDatum, error) {
+	return convertTuple(params, nil)
+}
+
+// convertTuple converts the columns of a record, names are used in the error message if it is given.
+func convertTuple(params []any, names []string) ([]*AppendDatum, error) {
 	tuple := make([]*AppendDatum, len(params))
 	for i, p := range params {
-		if p == nil {
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VNull{VNull: true}}
-			continue
-		}
-		switch v := p.(type) {
-		case *int:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VInt32{VInt32: int32(*v)}}
-		case int:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VInt32{VInt32: int32(v)}}
-		case *int8:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VInt32{VInt32: int32(*v)}}
-		case int8:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VInt32{VInt32: int32(v)}}
-		case *int16:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VInt32{VInt32: int32(*v)}}
-		case int16:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VInt32{VInt32: int32(v)}}
-		case *int32:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VInt32{VInt32: *v}}
-		case int32:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VInt32{VInt32: v}}
-		case *int64:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VInt64{VInt64: *v}}
-		case int64:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VInt64{VInt64: v}}
-		case *float32:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VFloat{VFloat: *v}}
-		case float32:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VFloat{VFloat: v}}
-		case *float64:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VDouble{VDouble: *v}}
-		case float64:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VDouble{VDouble: v}}
-		case *string:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VString{VString: *v}}
-		case string:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VString{VString: v}}
-		case *bool:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VBool{VBool: *v}}
-		case bool:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VBool{VBool: v}}
-		case []byte:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VBytes{VBytes: v}}
-		case *net.IP:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VIp{VIp: v.String()}}
-		case net.IP:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VIp{VIp: v.String()}}
-		case *time.Time:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VTime{VTime: v.UnixNano()}}
-		case time.Time:
-			tuple[i] = &AppendDatum{Value: &AppendDatum_VTime{VTime: v.UnixNano()}}
-		default:
-			return nil, fmt.Errorf("unsupported params[%d] type %T", i, p)
+		d, err := convertDatum(p, 0)
+		if err != nil {
+			if i < len(names) {
+				return nil, errors.Wrapf(err, "convert column %q", names[i])
+			}
+			return nil, errors.Wrapf(err, "convert column[%d]", i)
 		}
+		tuple[i] = d
 	}
 	return tuple, nil
 }
@@ -0,0 +1,110 @@
+package machrpc_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/machbase/neo-client/machrpc"
+	"github.com/stretchr/testify/require"
+)
+
+type Celsius float64
+
+type Point struct{ X, Y int }
+
+type ID int
+
+type Level int8
+
+type Flags uint
+
+func TestConvertAnyToPbTuple(t *testing.T) {
+	machrpc.RegisterCodec(func(p Point) (any, error) {
+		return fmt.Sprintf("%d,%d", p.X, p.Y), nil
+	})
+
+	ts := time.Unix(0, 1700000000123456789)
+	bs := []byte("bytes")
+	var nilInt *int
+	tests := []struct {
+		in     any
+		expect any
+	}{
+		{nil, nil},
+		{int(1), int64(1)},
+		{int(1 << 40), int64(1 << 40)},
+		{int16(-1), int32(-1)},
+		{uint(2), uint64(2)},
+		{uint(1 << 40), uint64(1 << 40)},
+		{uint8(3), uint32(3)},
+		{uint16(4), uint32(4)},
+		{uint32(5), uint32(5)},
+		{uint64(6), uint64(6)},
+		{true, true},
+		{&bs, []byte("bytes")},
+		{nilInt, nil},
+		{3 * time.Second, int64(3 * time.Second)},
+		{json.RawMessage(`{"a":1}`), `{"a":1}`},
+		{map[string]any{"a": 1}, `{"a":1}`},
+		{Celsius(36.5), float64(36.5)},
+		{ID(1 << 40), int64(1 << 40)},
+		{Level(3), int32(3)},
+		{Flags(1 << 40), uint64(1 << 40)},
+		{sql.NullString{String: "str", Valid: true}, "str"},
+		{sql.NullInt64{}, nil},
+		{&sql.NullFloat64{Float64: 1.5, Valid: true}, float64(1.5)},
+		{net.ParseIP("127.0.0.1"), "127.0.0.1"},
+		{ts, ts},
+		{Point{1, 2}, "1,2"},
+	}
+	for _, tt := range tests {
+		tuple, err := machrpc.ConvertAnyToPbTuple([]any{tt.in})
+		require.Nil(t, err, "%T", tt.in)
+		values, err := machrpc.ConvertPbTupleToAny(tuple)
+		require.Nil(t, err)
+		require.Equal(t, tt.expect, values[0], "%T", tt.in)
+	}
+
+	_, err := machrpc.ConvertAnyToPbTuple([]any{1, struct{}{}})
+	require.NotNil(t, err)
+	require.Equal(t, "convert column[1]: unsupported type struct {}", err.Error())
+}
+
+func TestConvertAnyToPb(t *testing.T) {
+	pbvals, err := machrpc.ConvertAnyToPb([]any{true, uint(7), Celsius(1.5), nil, time.Second})
+	require.Nil(t, err)
+	values := machrpc.ConvertPbToAny(pbvals[:3])
+	require.Equal(t, []any{true, uint64(7), float64(1.5)}, values)
+	require.Nil(t, pbvals[3])
+	require.Equal(t, []any{int64(time.Second)}, machrpc.ConvertPbToAny(pbvals[4:]))
+
+	_, err = machrpc.ConvertAnyToPb([]any{make(chan int)})
+	require.NotNil(t, err)
+	require.Equal(t, "convert params[0]: unsupported type chan int", err.Error())
+}
+
+func TestConvertTimeRange(t *testing.T) {
+	// the times that int64 nanoseconds can not represent
+	times := []time.Time{
+		{},
+		time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, ts := range times {
+		// query params keep the time as it is
+		pbvals, err := machrpc.ConvertAnyToPb([]any{ts, &ts})
+		require.Nil(t, err, ts)
+		values := machrpc.ConvertPbToAny(pbvals)
+		require.True(t, ts.Equal(values[0].(time.Time)), ts)
+		require.True(t, ts.Equal(values[1].(time.Time)), ts)
+
+		// append columns in nanoseconds can not wrap around
+		_, err = machrpc.ConvertAnyToPbTuple([]any{ts})
+		require.NotNil(t, err, ts)
+		require.Contains(t, err.Error(), "out of range")
+	}
+}
@@ -1,16 +1,38 @@
 package machrpc_test
 
 import (
+	"bytes"
 	context "context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/machbase/neo-client/machrpc"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
 )
 
+var mockServer *MockServer
+
 func TestMain(m *testing.M) {
 	svr := &MockServer{}
+	mockServer = svr
 
 	err := svr.Start()
 	if err != nil {
@@ -22,7 +44,7 @@ func TestMain(m *testing.M) {
 	svr.Stop()
 }
 
-func newClient(t *testing.T) *machrpc.Client {
+func newClient(t testing.TB) *machrpc.Client {
 	t.Helper()
 	cli, err := machrpc.NewClient(&machrpc.Config{
 		ServerAddr:    MockServerAddr,
@@ -35,7 +57,26 @@ func newClient(t *testing.T) *machrpc.Client {
 	return cli
 }
 
-func newConn(t *testing.T) *machrpc.Conn {
+// startServer serves the mock server with the options on another port, it returns the address.
+func startServer(t *testing.T, opts ...grpc.ServerOption) string {
+	t.Helper()
+	_, addr := listenServer(t, "127.0.0.1:0", opts...)
+	return addr
+}
+
+// listenServer serves the mock server on the address, it returns the server and the address.
+func listenServer(t *testing.T, addr string, opts ...grpc.ServerOption) (*grpc.Server, string) {
+	t.Helper()
+	svr := grpc.NewServer(opts...)
+	machrpc.RegisterMachbaseServer(svr, mockServer)
+	lsnr, err := net.Listen("tcp", addr)
+	require.Nil(t, err)
+	go svr.Serve(lsnr)
+	t.Cleanup(svr.Stop)
+	return svr, lsnr.Addr().String()
+}
+
+func newConn(t testing.TB) *machrpc.Conn {
 	t.Helper()
 	cli := newClient(t)
 	conn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
@@ -51,7 +92,7 @@ func TestAuth(t *testing.T) {
 	}
 	ok, err := cli.UserAuth("sys", "mm")
 	require.NotNil(t, err)
-	require.Equal(t, "invalid username or password", err.Error())
+	require.Equal(t, "UserAuth: invalid username or password", err.Error())
 	require.False(t, ok)
 
 	ok, err = cli.UserAuth("sys", "manager")
@@ -88,7 +129,7 @@ func TestNewClient(t *testing.T) {
 	// wrong password
 	conn, err = cli.Connect(ctx, machrpc.WithPassword("sys", "mm"))
 	require.NotNil(t, err)
-	require.Equal(t, "invalid username or password", err.Error())
+	require.Equal(t, "Conn: invalid username or password", err.Error())
 	require.Nil(t, conn)
 
 	// correct username, password
@@ -132,6 +173,27 @@ func TestPing(t *testing.T) {
 	pinger.Close()
 }
 
+func TestConnContext(t *testing.T) {
+	cli := newClient(t)
+	ctx, cancel := context.WithCancel(context.TODO())
+	conn, err := cli.Connect(ctx, machrpc.WithPassword("sys", "manager"))
+	require.Nil(t, err)
+	// the context of Connect is not used after Connect returns
+	cancel()
+
+	_, err = conn.Ping()
+	require.Nil(t, err)
+	_, err = conn.PingContext(context.TODO())
+	require.Nil(t, err)
+
+	canceled, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err = conn.PingContext(canceled)
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.Nil(t, conn.CloseContext(context.TODO()))
+}
+
 type Explainer interface {
 	// Explain retrieves execution plan of the given SQL statement.
 	Explain(ctx context.Context, sqlText string, full bool) (string, error)
@@ -233,3 +295,1571 @@ func TestAppend(t *testing.T) {
 	require.Equal(t, int64(10), succ)
 	require.Equal(t, int64(0), fail)
 }
+
+func TestPool(t *testing.T) {
+	cli := newClient(t)
+	pool, err := machrpc.NewPool(cli,
+		machrpc.PoolConnectOptions(machrpc.WithPassword("sys", "manager")),
+		machrpc.PoolMaxOpenConns(2),
+		machrpc.PoolMaxIdleConns(1),
+	)
+	require.Nil(t, err)
+	defer pool.Close()
+
+	c1, err := pool.Get(context.TODO())
+	require.Nil(t, err)
+	c2, err := pool.Get(context.TODO())
+	require.Nil(t, err)
+	require.Equal(t, 2, pool.Stats().InUse)
+
+	// max open reached, wait until timeout
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	_, err = pool.Get(ctx)
+	cancel()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// returned conn is handed over to the waiter
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		pool.Put(c1)
+	}()
+	c3, err := pool.Get(context.TODO())
+	require.Nil(t, err)
+	require.Same(t, c1, c3)
+
+	pool.Put(c2)
+	pool.Put(c3) // exceeds max idle conns
+	stats := pool.Stats()
+	require.Equal(t, 1, stats.Idle)
+	require.Equal(t, 1, stats.OpenConns)
+	require.Equal(t, int64(1), stats.MaxIdleClosed)
+	require.Equal(t, int64(2), stats.ConnectionsCreated)
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := pool.Get(context.TODO())
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer pool.Put(conn)
+			row := conn.QueryRow(context.TODO(), "select count(*) from example where name = ?", "query1")
+			errs <- row.Err()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.Nil(t, err)
+	}
+	require.LessOrEqual(t, pool.Stats().OpenConns, 2)
+}
+
+func TestPoolIdleEviction(t *testing.T) {
+	cli := newClient(t)
+	pool, err := machrpc.NewPool(cli,
+		machrpc.PoolConnectOptions(machrpc.WithPassword("sys", "manager")),
+		machrpc.PoolMaxIdleTime(20*time.Millisecond),
+	)
+	require.Nil(t, err)
+
+	conn, err := pool.Get(context.TODO())
+	require.Nil(t, err)
+	pool.Put(conn)
+	require.Equal(t, 1, pool.Stats().Idle)
+
+	require.Eventually(t, func() bool { return pool.Stats().Idle == 0 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, int64(1), pool.Stats().MaxIdleTimeClosed)
+
+	require.Nil(t, pool.Close())
+	_, err = pool.Get(context.TODO())
+	require.ErrorIs(t, err, machrpc.ErrPoolClosed)
+}
+
+func TestReconnect(t *testing.T) {
+	cli, err := machrpc.NewClient(&machrpc.Config{
+		ServerAddr: MockServerAddr,
+		Retry: &machrpc.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+		},
+	})
+	require.Nil(t, err)
+	conn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+	require.Nil(t, err)
+	defer conn.Close()
+
+	// idempotent call re-establishes the session transparently
+	mockServer.ResetSessions()
+	_, err = conn.Ping()
+	require.Nil(t, err)
+	row := conn.QueryRow(context.TODO(), "select count(*) from example where name = ?", "query1")
+	require.Nil(t, row.Err())
+
+	// non-idempotent call reports the lost session
+	mockServer.ResetSessions()
+	result := conn.Exec(context.TODO(), "insert into example (name, time, value) values(?, ?, ?)", 1, 2, 3)
+	require.ErrorIs(t, result.Err(), machrpc.ErrSessionLost)
+
+	// and the next call works with a new session
+	result = conn.Exec(context.TODO(), "insert into example (name, time, value) values(?, ?, ?)", 1, 2, 3)
+	require.Nil(t, result.Err())
+	require.Equal(t, int64(1), result.RowsAffected())
+}
+
+func TestNoRetryPolicy(t *testing.T) {
+	// the server fails the calls except Conn and ConnClose while failing is set
+	var failing atomic.Bool
+	addr := startServer(t, grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if failing.Load() && !strings.HasSuffix(info.FullMethod, "/Conn") && !strings.HasSuffix(info.FullMethod, "/ConnClose") {
+			return nil, status.Error(codes.Unavailable, "server is busy")
+		}
+		return handler(ctx, req)
+	}), grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if failing.Load() {
+			return status.Error(codes.Unavailable, "server is busy")
+		}
+		return handler(srv, ss)
+	}))
+	cli, err := machrpc.NewClient(&machrpc.Config{ServerAddr: addr})
+	require.Nil(t, err)
+	defer cli.Close()
+	conn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+	require.Nil(t, err)
+	defer conn.Close()
+
+	failing.Store(true)
+	_, err = conn.Ping()
+	require.ErrorIs(t, err, machrpc.ErrUnavailable)
+	_, err = conn.Explain(context.TODO(), "select * from dummy", false)
+	require.ErrorIs(t, err, machrpc.ErrUnavailable)
+	row := conn.QueryRow(context.TODO(), "select count(*) from example where name = ?", "query1")
+	require.ErrorIs(t, row.Err(), machrpc.ErrUnavailable)
+	_, err = conn.Query(context.TODO(), "select * from example where name = ?", "query1")
+	require.ErrorIs(t, err, machrpc.ErrUnavailable)
+	_, err = conn.Appender(context.TODO(), "example")
+	require.NotNil(t, err)
+	// the non-idempotent call does not report the session as lost, it may be alive
+	result := conn.Exec(context.TODO(), "insert into example (name, time, value) values(?, ?, ?)", 1, 2, 3)
+	require.ErrorIs(t, result.Err(), machrpc.ErrUnavailable)
+	require.NotErrorIs(t, result.Err(), machrpc.ErrSessionLost)
+
+	// the session is still alive when the server recovers
+	failing.Store(false)
+	result = conn.Exec(context.TODO(), "insert into example (name, time, value) values(?, ?, ?)", 1, 2, 3)
+	require.Nil(t, result.Err())
+}
+
+func TestQueryBatchFetch(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	for _, tc := range []struct {
+		param string
+		count int
+	}{
+		{"query2", 2500}, // multiple batches
+		{"legacy", 3},    // server that does not support batched fetch
+	} {
+		rows, err := conn.Query(context.TODO(), "select * from example where name = ?", tc.param)
+		require.Nil(t, err)
+
+		var name string
+		var ts time.Time
+		var value float64
+		count := 0
+		for rows.Next() {
+			err := rows.Scan(&name, &ts, &value)
+			require.Nil(t, err)
+			count++
+			require.Equal(t, int64(count), ts.UnixNano())
+		}
+		require.Equal(t, tc.count, count, tc.param)
+		require.Nil(t, rows.Close())
+	}
+}
+
+type TestBase struct {
+	Name string `machbase:"name"`
+}
+
+type TestRecord struct {
+	TestBase
+	Timestamp time.Time `machbase:"time"`
+	Value     float64
+	Ignored   string `machbase:"-"`
+}
+
+func TestQueryCancel(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+	killed := len(mockServer.KilledSessions())
+
+	// the rows are closed on the server when the context is cancelled mid-fetch
+	ctx, cancel := context.WithCancel(context.TODO())
+	rows, err := conn.Query(ctx, "select * from example where name = ?", "query2")
+	require.Nil(t, err)
+	require.True(t, rows.Next())
+	require.True(t, mockServer.RowsOpened("query2#1"))
+	cancel()
+	require.Eventually(t, func() bool { return !mockServer.RowsOpened("query2#1") }, time.Second, time.Millisecond)
+	require.False(t, rows.Next())
+	require.ErrorIs(t, rows.Err(), context.Canceled)
+	require.Nil(t, rows.Close())
+
+	// the session is not killed without WithKillOnCancel
+	ctx, cancel = context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	_, err = conn.Query(ctx, "select * from slow_table")
+	require.ErrorIs(t, err, machrpc.ErrTimeout)
+	require.Len(t, mockServer.KilledSessions(), killed)
+	_, err = conn.Ping()
+	require.Nil(t, err)
+}
+
+func TestKillOnCancel(t *testing.T) {
+	cli := newClient(t)
+	conn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"), machrpc.WithKillOnCancel(5*time.Millisecond))
+	require.Nil(t, err)
+	defer conn.Close()
+	killed := len(mockServer.KilledSessions())
+
+	// a short statement is not killed
+	ctx, cancel := context.WithCancel(context.TODO())
+	rows, err := conn.Query(ctx, "select * from example where name = ?", "query2")
+	require.Nil(t, err)
+	cancel()
+	require.Eventually(t, func() bool { return !mockServer.RowsOpened("query2#1") }, time.Second, time.Millisecond)
+	require.Nil(t, rows.Close())
+	require.Len(t, mockServer.KilledSessions(), killed)
+
+	// a long-running statement kills the session
+	ctx, cancel = context.WithTimeout(context.TODO(), 20*time.Millisecond)
+	defer cancel()
+	_, err = conn.Query(ctx, "select * from slow_table")
+	require.ErrorIs(t, err, machrpc.ErrTimeout)
+	require.Len(t, mockServer.KilledSessions(), killed+1)
+
+	// and the next call works with a new session
+	_, err = conn.Ping()
+	require.Nil(t, err)
+}
+
+func TestScanStruct(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	rows, err := conn.Query(context.TODO(), "select * from example where name = ?", "query1")
+	require.Nil(t, err)
+	var recs []TestRecord
+	for rows.Next() {
+		rec := TestRecord{}
+		require.Nil(t, rows.ScanStruct(&rec))
+		recs = append(recs, rec)
+	}
+	rows.Close()
+	require.Equal(t, 1, len(recs))
+	require.Equal(t, "tag", recs[0].Name)
+	require.Equal(t, int64(1), recs[0].Timestamp.UnixNano())
+	require.Equal(t, 3.14, recs[0].Value)
+
+	// strict mode reports unmapped column
+	rows, err = conn.Query(context.TODO(), "select * from example where name = ?", "query1")
+	require.Nil(t, err)
+	require.True(t, rows.Next())
+	partial := struct{ Name string }{}
+	require.Nil(t, rows.ScanStruct(&partial))
+	require.Equal(t, "tag", partial.Name)
+	err = rows.ScanStruct(&partial, machrpc.ScanStrict())
+	require.NotNil(t, err)
+	require.Equal(t, `column "time" has no matching field in *struct { Name string }`, err.Error())
+	rows.Close()
+
+	row := conn.QueryRow(context.TODO(), "select count(*) from example where name = ?", "query1")
+	cnt := struct {
+		Count int `machbase:"count(*)"`
+	}{}
+	require.Nil(t, row.ScanStruct(&cnt, machrpc.ScanStrict()))
+	require.Equal(t, 123, cnt.Count)
+
+	// the values are not mapped by the position without the column names
+	row = conn.QueryRow(context.TODO(), "select count(*) from legacy_example")
+	require.Nil(t, row.Err())
+	require.ErrorIs(t, row.ScanStruct(&cnt), machrpc.ErrNoColumnNames)
+	require.Nil(t, row.Scan(&cnt.Count))
+
+	rows, err = conn.Query(context.TODO(), "select * from example where name = ?", "query1")
+	require.Nil(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+	// the ambiguous name is ignored
+	ambiguous := struct {
+		nameField
+		otherNameField
+		Value float64
+	}{}
+	require.Nil(t, rows.ScanStruct(&ambiguous))
+	require.Equal(t, "", ambiguous.nameField.Name)
+	require.Equal(t, "", ambiguous.otherNameField.Name)
+	require.Equal(t, 3.14, ambiguous.Value)
+	require.NotNil(t, rows.ScanStruct(&ambiguous, machrpc.ScanStrict()))
+	// the tagged field wins at the same depth
+	tagged := struct {
+		nameField
+		taggedNameField
+	}{}
+	require.Nil(t, rows.ScanStruct(&tagged))
+	require.Equal(t, "", tagged.nameField.Name)
+	require.Equal(t, "tag", tagged.Label)
+	// the nil pointer to unexported embedded struct can not be allocated
+	unexported := struct {
+		*nameField
+	}{}
+	err = rows.ScanStruct(&unexported)
+	require.NotNil(t, err)
+	require.Equal(t, `column "name": cannot set embedded pointer to unexported struct machrpc_test.nameField`, err.Error())
+	unexported.nameField = &nameField{}
+	require.Nil(t, rows.ScanStruct(&unexported))
+	require.Equal(t, "tag", unexported.Name)
+}
+
+type nameField struct{ Name string }
+
+type otherNameField struct{ Name string }
+
+type taggedNameField struct {
+	Label string `machbase:"name"`
+}
+
+func TestQueryGeneric(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	recs, err := machrpc.QueryAll[TestRecord](context.TODO(), conn, "select * from example where name = ?", "query2")
+	require.Nil(t, err)
+	require.Equal(t, 2500, len(recs))
+	require.Equal(t, int64(2500), recs[2499].Timestamp.UnixNano())
+
+	ptrs, err := machrpc.QueryAll[*TestRecord](context.TODO(), conn, "select * from example where name = ?", "query1")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(ptrs))
+	require.Equal(t, "tag", ptrs[0].Name)
+
+	name, err := machrpc.QueryOne[string](context.TODO(), conn, "select * from example where name = ?", "query2")
+	require.Nil(t, err)
+	require.Equal(t, "tag", name)
+
+	m, err := machrpc.QueryOne[map[string]any](context.TODO(), conn, "select * from example where name = ?", "query1")
+	require.Nil(t, err)
+	require.Equal(t, "tag", m["name"])
+	require.Equal(t, 3.14, m["value"])
+
+	// NULL is nil in the map
+	m, err = machrpc.QueryOne[map[string]any](context.TODO(), conn, "select * from example where name = ?", "nulls")
+	require.Nil(t, err)
+	require.Equal(t, "tag", m["name"])
+	require.Contains(t, m, "value")
+	require.Nil(t, m["value"])
+
+	count := 0
+	err = machrpc.QueryEach(context.TODO(), conn, "select * from example where name = ?", func(rec TestRecord) error {
+		count++
+		if count == 10 {
+			return context.Canceled
+		}
+		return nil
+	}, "query2")
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 10, count)
+
+	_, err = machrpc.QueryAll[TestRecord](context.TODO(), conn, "select * from example where name = ?", "unknown")
+	require.NotNil(t, err)
+}
+
+func TestColumnTypes(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+	rows, err := conn.Query(context.TODO(), "select * from example where name = ?", "query1")
+	require.Nil(t, err)
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	require.Nil(t, err)
+	require.Equal(t, 3, len(types))
+	require.Equal(t, "name", types[0].Name)
+	require.Equal(t, machrpc.ColumnType(machrpc.VarcharColumnType), types[0].Type)
+	require.Equal(t, reflect.TypeOf(""), types[0].ScanType)
+	require.Equal(t, 40, types[0].Size)
+	require.True(t, types[0].Nullable)
+	require.Equal(t, machrpc.ColumnType(machrpc.DatetimeColumnType), types[1].Type)
+	require.Equal(t, reflect.TypeOf(time.Time{}), types[1].ScanType)
+	require.Equal(t, "double", types[2].Type.String())
+
+	// cached
+	again, err := rows.ColumnTypes()
+	require.Nil(t, err)
+	require.Same(t, types[0], again[0])
+}
+
+func TestAppendError(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	var handlerErr error
+	var handlerStats machrpc.AppenderStats
+	var handlerLock sync.Mutex
+	var appender *machrpc.Appender
+	results := make(chan machrpc.FlushResult, 100)
+	appender, err := conn.Appender(context.TODO(), "broken",
+		machrpc.AppenderBufferThreshold(1),
+		machrpc.AppenderFlushResults(results),
+		machrpc.AppenderErrorHandler(func(err error) {
+			// the handler can call the appender
+			stats := appender.Stats()
+			appender.Flush(context.TODO())
+			handlerLock.Lock()
+			handlerErr, handlerStats = err, stats
+			handlerLock.Unlock()
+		}),
+	)
+	require.Nil(t, err)
+
+	for i := 0; i < 100 && err == nil; i++ {
+		err = appender.Append(i)
+		time.Sleep(time.Millisecond)
+	}
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "mock append failure")
+	require.Equal(t, err, appender.Err())
+
+	handlerLock.Lock()
+	require.Contains(t, handlerErr.Error(), "mock append failure")
+	require.Equal(t, int64(1), handlerStats.SendErrors)
+	handlerLock.Unlock()
+
+	var lastResult machrpc.FlushResult
+	for len(results) > 0 {
+		lastResult = <-results
+	}
+	require.NotNil(t, lastResult.Err)
+	require.Equal(t, 1, lastResult.Records)
+
+	_, _, err = appender.Close()
+	require.NotNil(t, err)
+}
+
+func TestAppendSpool(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	spoolConf := machrpc.SpoolConfig{Dir: t.TempDir(), SegmentSize: 512}
+	results := make(chan machrpc.FlushResult, 1000)
+	appender, err := conn.Appender(context.TODO(), "example",
+		machrpc.AppenderBufferThreshold(10),
+		machrpc.AppenderSpool(spoolConf),
+		machrpc.AppenderFlushResults(results),
+	)
+	require.Nil(t, err)
+
+	// server outage
+	mockServer.SetAppendFail(true)
+	for i := 0; i < 100; i++ {
+		require.Nil(t, appender.Append(fmt.Sprintf("name-%d", i), time.Now(), float64(i)))
+		time.Sleep(time.Millisecond)
+	}
+	_, _, err = appender.Close()
+	require.NotNil(t, err)
+	require.Nil(t, appender.Err(), "spooled records are not fatal")
+
+	// records that were passed to the stream before the failure is detected are lost
+	lost := 0
+	for len(results) > 0 {
+		if r := <-results; r.Err == nil {
+			lost += r.Records
+		}
+	}
+	segments, _ := filepath.Glob(filepath.Join(spoolConf.Dir, "EXAMPLE", "*.spool"))
+	require.Greater(t, len(segments), 1)
+
+	// server recovered, new appender replays the spool
+	mockServer.SetAppendFail(false)
+	appender, err = conn.Appender(context.TODO(), "example",
+		machrpc.AppenderBufferThreshold(10),
+		machrpc.AppenderSpool(spoolConf),
+	)
+	require.Nil(t, err)
+	succ, fail, err := appender.Close()
+	require.Nil(t, err)
+	require.Equal(t, int64(100-lost), succ)
+	require.Equal(t, int64(0), fail)
+
+	segments, _ = filepath.Glob(filepath.Join(spoolConf.Dir, "EXAMPLE", "*.spool"))
+	require.Equal(t, 0, len(segments))
+
+	// the spool directory is used by an appender at a time
+	appender, err = conn.Appender(context.TODO(), "example", machrpc.AppenderSpool(spoolConf))
+	require.Nil(t, err)
+	_, err = conn.Appender(context.TODO(), "example", machrpc.AppenderSpool(spoolConf))
+	require.ErrorIs(t, err, machrpc.ErrSpoolLocked)
+	_, _, err = appender.Close()
+	require.Nil(t, err)
+
+	// the record of the corrupted length is discarded with the rest of the segment
+	torn := binary.BigEndian.AppendUint32(nil, 0xFFFFFFF0)
+	torn = binary.BigEndian.AppendUint32(torn, 0)
+	require.Nil(t, os.WriteFile(filepath.Join(spoolConf.Dir, "EXAMPLE", "00000000000000000001.spool"), torn, 0644))
+	appender, err = conn.Appender(context.TODO(), "example", machrpc.AppenderSpool(spoolConf))
+	require.Nil(t, err)
+	succ, _, err = appender.Close()
+	require.Nil(t, err)
+	require.Equal(t, int64(0), succ)
+	segments, _ = filepath.Glob(filepath.Join(spoolConf.Dir, "EXAMPLE", "*.spool"))
+	require.Equal(t, 0, len(segments))
+}
+
+func TestAppendBufferFull(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy machrpc.BufferFullPolicy
+	}{
+		{"error", machrpc.BufferFullError},
+		{"block", machrpc.BufferFullBlock},
+		{"drop_oldest", machrpc.BufferFullDropOldest},
+		{"drop_newest", machrpc.BufferFullDropNewest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := newConn(t)
+			defer conn.Close()
+
+			results := make(chan machrpc.FlushResult, 1000)
+			appender, err := conn.Appender(context.TODO(), "example",
+				machrpc.AppenderBufferThreshold(10),
+				machrpc.AppenderMaxBuffer(20, 0, tt.policy),
+				// the spool can not hold any record, records are kept in the buffer
+				machrpc.AppenderSpool(machrpc.SpoolConfig{Dir: t.TempDir(), MaxSize: 1}),
+				machrpc.AppenderFlushResults(results),
+			)
+			require.Nil(t, err)
+
+			mockServer.SetAppendFail(true)
+			defer mockServer.SetAppendFail(false)
+
+			var appendErr error
+			appended := 0
+			for i := 0; i < 100 && appendErr == nil; i++ {
+				ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+				appendErr = appender.AppendContext(ctx, fmt.Sprintf("name-%d", i), time.Now(), float64(i))
+				cancel()
+				if appendErr == nil {
+					appended++
+				}
+				time.Sleep(time.Millisecond)
+			}
+			switch tt.policy {
+			case machrpc.BufferFullError:
+				require.ErrorIs(t, appendErr, machrpc.ErrBufferFull)
+				require.Equal(t, int64(0), appender.Dropped())
+			case machrpc.BufferFullBlock:
+				require.ErrorIs(t, appendErr, context.DeadlineExceeded)
+				require.Equal(t, int64(0), appender.Dropped())
+			default:
+				require.Nil(t, appendErr)
+				require.Equal(t, 100, appended)
+				// records passed to the stream before the failure is detected
+				sent := 0
+				for len(results) > 0 {
+					if r := <-results; r.Err == nil {
+						sent += r.Records
+					}
+				}
+				require.Equal(t, int64(100-20-sent), appender.Dropped())
+				if tt.policy == machrpc.BufferFullDropNewest {
+					// the dropped records are not counted as appended
+					require.Equal(t, 100-appender.Dropped(), appender.Stats().Appended)
+					ts := time.Now()
+					n, err := appender.AppendColumns([]string{"name-a", "name-b"}, []time.Time{ts, ts}, []float64{1, 2})
+					require.Nil(t, err)
+					require.Equal(t, 0, n)
+					require.Equal(t, int64(100-20-sent+2), appender.Dropped())
+				}
+			}
+			appender.Close()
+		})
+	}
+}
+
+func TestAppendCloseRace(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	appender, err := conn.Appender(context.TODO(), "example", machrpc.AppenderBufferThreshold(1))
+	require.Nil(t, err)
+
+	// Close while the other goroutines are appending and flushing by the threshold
+	errs := make(chan error, 8)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if err := appender.Append("name", time.Now(), 1.0); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	require.Eventually(t, func() bool {
+		return appender.Stats().Appended >= 20
+	}, 5*time.Second, time.Millisecond)
+	_, _, err = appender.Close()
+	require.Nil(t, err)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.ErrorIs(t, err, sql.ErrTxDone)
+	}
+}
+
+func TestAppendFlushPolicy(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	appendRecords := func(appender *machrpc.Appender, n int) {
+		for i := 0; i < n; i++ {
+			require.Nil(t, appender.Append(fmt.Sprintf("name-%d", i), time.Now(), float64(i)))
+		}
+	}
+
+	// interval
+	results := make(chan machrpc.FlushResult, 100)
+	appender, err := conn.Appender(context.TODO(), "example",
+		machrpc.AppenderFlushInterval(20*time.Millisecond),
+		machrpc.AppenderFlushResults(results),
+	)
+	require.Nil(t, err)
+	appendRecords(appender, 5)
+	select {
+	case r := <-results:
+		require.Nil(t, r.Err)
+		require.Equal(t, 5, r.Records)
+	case <-time.After(time.Second):
+		t.Fatal("interval flush timed out")
+	}
+	succ, _, err := appender.Close()
+	require.Nil(t, err)
+	require.Equal(t, int64(5), succ)
+
+	// bytes
+	results = make(chan machrpc.FlushResult, 100)
+	appender, err = conn.Appender(context.TODO(), "example",
+		machrpc.AppenderFlushInterval(0),
+		machrpc.AppenderFlushBytes(200),
+		machrpc.AppenderFlushResults(results),
+	)
+	require.Nil(t, err)
+	appendRecords(appender, 20)
+	require.Greater(t, len(results), 0)
+	r := <-results
+	require.Nil(t, r.Err)
+	require.Less(t, r.Records, 20)
+	appender.Close()
+
+	// explicit flush
+	results = make(chan machrpc.FlushResult, 100)
+	appender, err = conn.Appender(context.TODO(), "example",
+		machrpc.AppenderFlushInterval(0),
+		machrpc.AppenderFlushResults(results),
+	)
+	require.Nil(t, err)
+	appendRecords(appender, 3)
+	require.Equal(t, 0, len(results))
+	require.Nil(t, appender.Flush(context.TODO()))
+	require.Equal(t, 1, len(results))
+	require.Equal(t, 3, (<-results).Records)
+	appender.Close()
+	require.ErrorIs(t, appender.Flush(context.TODO()), sql.ErrTxDone)
+
+	// ctx of the flush aborts the stream that the server does not receive
+	mockServer.SetAppendHang(true)
+	appender, err = conn.Appender(context.TODO(), "example",
+		machrpc.AppenderFlushInterval(0),
+		machrpc.AppenderBufferThreshold(100000),
+	)
+	require.Nil(t, err)
+	note := strings.Repeat("x", 1000)
+	for i := 0; i < 10000; i++ {
+		require.Nil(t, appender.Append(note, time.Now(), float64(i)))
+	}
+	// the first batch exhausts the flow control window of the stream
+	require.Nil(t, appender.Flush(context.TODO()))
+	require.Nil(t, appender.Append(note, time.Now(), 1.0))
+	flushCtx, flushCancel := context.WithTimeout(context.TODO(), 200*time.Millisecond)
+	tick := time.Now()
+	require.ErrorIs(t, appender.Flush(flushCtx), context.DeadlineExceeded)
+	flushCancel()
+	require.Less(t, time.Since(tick), 5*time.Second)
+	require.NotNil(t, appender.Err())
+	appender.Close()
+	mockServer.SetAppendHang(false)
+
+	// ctx of the appender
+	ctx, cancel := context.WithCancel(context.TODO())
+	appender, err = conn.Appender(ctx, "example")
+	require.Nil(t, err)
+	appendRecords(appender, 3)
+	cancel()
+	require.ErrorIs(t, appender.Append("name", time.Now(), 1.0), context.Canceled)
+	succ, _, err = appender.Close()
+	require.Nil(t, err)
+	require.Equal(t, int64(3), succ)
+}
+
+type TagData struct {
+	Name  string    `machbase:"name"`
+	Time  time.Time `machbase:"time"`
+	Value float64
+	Note  string `machbase:"-"`
+}
+
+func TestAppendStruct(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	appender, err := conn.Appender(context.TODO(), "tagdata")
+	require.Nil(t, err)
+
+	names, types, err := appender.Columns()
+	require.Nil(t, err)
+	require.Equal(t, []string{"NAME", "TIME", "VALUE"}, names)
+	require.Equal(t, []string{"varchar", "datetime", "double"}, types)
+
+	ts := time.Unix(0, 1700000000000000000)
+	require.Nil(t, appender.AppendStruct(TagData{Name: "tag1", Time: ts, Value: 1.5}))
+	require.Nil(t, appender.AppendStruct(&TagData{Name: "tag2", Time: ts, Value: 2.5}))
+	require.Nil(t, appender.AppendStructs([]TagData{
+		{Name: "tag3", Time: ts, Value: 3.5},
+		{Name: "tag4", Time: ts, Value: 4.5},
+	}))
+	// the column that has no matching field is NULL
+	require.Nil(t, appender.AppendStruct(struct {
+		Name  string
+		Value float64
+	}{"tag5", 5.5}))
+	// the field that has no matching column
+	err = appender.AppendStruct(struct{ Name, Unknown string }{"tag6", ""})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), `field "Unknown"`)
+	// arity
+	err = appender.Append("tag7", ts)
+	require.NotNil(t, err)
+	require.Equal(t, "2 values, but table TAGDATA has 3 columns", err.Error())
+	err = appender.Append("tag7", ts, "not a number")
+	require.Nil(t, err)
+	err = appender.Append("tag7", ts, make(chan int))
+	require.NotNil(t, err)
+	require.Equal(t, `convert column "VALUE": unsupported type chan int`, err.Error())
+
+	succ, fail, err := appender.Close()
+	require.Nil(t, err)
+	require.Equal(t, int64(6), succ)
+	require.Equal(t, int64(0), fail)
+
+	recs := mockServer.AppendedRecords("tagdata")
+	require.Equal(t, 6, len(recs))
+	require.Equal(t, []any{"tag1", ts, 1.5}, recs[0])
+	require.Equal(t, []any{"tag4", ts, 4.5}, recs[3])
+	require.Equal(t, []any{"tag5", nil, 5.5}, recs[4])
+
+	// the server does not report the columns, they are queried from the table
+	appender, err = conn.Appender(context.TODO(), "legacy_tagdata", machrpc.AppenderTimeformat("ms"))
+	require.Nil(t, err)
+	names, _, err = appender.Columns()
+	require.Nil(t, err)
+	require.Equal(t, []string{"name", "time", "value"}, names)
+	require.Nil(t, appender.AppendStruct(TagData{Name: "tag1", Time: ts, Value: 1.5}))
+	require.Nil(t, appender.Append("tag2", ts.UnixMilli(), 2.5))
+	succ, _, err = appender.Close()
+	require.Nil(t, err)
+	require.Equal(t, int64(2), succ)
+	recs = mockServer.AppendedRecords("legacy_tagdata")
+	require.Equal(t, []any{"tag1", ts, 1.5}, recs[0])
+	require.Equal(t, []any{"tag2", ts, 2.5}, recs[1])
+
+	// the query of the columns fails
+	appender, err = conn.Appender(context.TODO(), "example")
+	require.Nil(t, err)
+	_, _, err = appender.Columns()
+	require.NotNil(t, err)
+	require.NotNil(t, appender.AppendStruct(TagData{}))
+	appender.Close()
+}
+
+func TestAppendColumns(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	appender, err := conn.Appender(context.TODO(), "tagdata", machrpc.AppenderBufferThreshold(2))
+	require.Nil(t, err)
+
+	ts := time.Unix(0, 1700000000000000000)
+	n, err := appender.AppendColumns(
+		[]string{"tag1", "tag2", "tag3"},
+		[]time.Time{ts, ts.Add(time.Second), ts.Add(2 * time.Second)},
+		[]float64{1.5, 2.5, 3.5})
+	require.Nil(t, err)
+	require.Equal(t, 3, n)
+	n, err = appender.AppendColumns([]string{"tag4", "tag5"}, []time.Time{ts, ts}, []any{1.5, nil})
+	require.Nil(t, err)
+	require.Equal(t, 2, n)
+	// int is not truncated
+	n, err = appender.AppendColumns([]string{"tag6"}, []time.Time{ts}, []int{1 << 40})
+	require.Nil(t, err)
+	require.Equal(t, 1, n)
+
+	n, err = appender.AppendColumns([]string{"tag7"}, []time.Time{ts})
+	require.NotNil(t, err)
+	require.Equal(t, 0, n)
+	require.Equal(t, "2 values, but table TAGDATA has 3 columns", err.Error())
+	_, err = appender.AppendColumns([]string{"tag7"}, []time.Time{ts}, []float64{1, 2})
+	require.NotNil(t, err)
+	require.Equal(t, `convert column "VALUE": 2 values, but 1 records`, err.Error())
+	_, err = appender.AppendColumns([]string{"tag7"}, []time.Time{ts, ts}, []float64{1})
+	require.NotNil(t, err)
+	require.Equal(t, "1 names, but 2 times", err.Error())
+	_, err = appender.AppendColumns([]string{"tag7"}, []time.Time{{}}, []float64{1})
+	require.NotNil(t, err)
+	require.Equal(t, `convert column "TIME": time 0001-01-01T00:00:00Z is out of range`, err.Error())
+
+	succ, fail, err := appender.Close()
+	require.Nil(t, err)
+	require.Equal(t, int64(6), succ)
+	require.Equal(t, int64(0), fail)
+
+	recs := mockServer.AppendedRecords("tagdata")
+	require.Equal(t, 6, len(recs))
+	require.Equal(t, []any{"tag1", ts, 1.5}, recs[0])
+	require.Equal(t, []any{"tag3", ts.Add(2 * time.Second), 3.5}, recs[2])
+	require.Equal(t, []any{"tag5", ts, nil}, recs[4])
+	require.Equal(t, []any{"tag6", ts, int64(1 << 40)}, recs[5])
+
+	// the count of the records appended before the failure
+	appender, err = conn.Appender(context.TODO(), "broken",
+		machrpc.AppenderFlushInterval(0),
+		machrpc.AppenderBufferThreshold(2))
+	require.Nil(t, err)
+	require.Nil(t, appender.Append("tag0", ts, 0.0))
+	// the server aborts the stream on the first batch
+	require.Nil(t, appender.Flush(context.TODO()))
+	time.Sleep(200 * time.Millisecond)
+	n, err = appender.AppendColumns([]string{"tag1", "tag2", "tag3"}, []time.Time{ts, ts, ts}, []float64{1, 2, 3})
+	require.NotNil(t, err)
+	require.Equal(t, 1, n)
+	appender.Close()
+}
+
+const benchBatchSize = 1000
+
+func BenchmarkAppend(b *testing.B) {
+	conn := newConn(b)
+	defer conn.Close()
+
+	appender, err := conn.Appender(context.TODO(), "example", machrpc.AppenderBufferThreshold(benchBatchSize))
+	require.Nil(b, err)
+	defer appender.Close()
+
+	ts := time.Now()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < benchBatchSize; n++ {
+			if err := appender.Append("tag", ts, float64(n)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkAppendColumns(b *testing.B) {
+	conn := newConn(b)
+	defer conn.Close()
+
+	appender, err := conn.Appender(context.TODO(), "example", machrpc.AppenderBufferThreshold(benchBatchSize))
+	require.Nil(b, err)
+	defer appender.Close()
+
+	names := make([]string, benchBatchSize)
+	times := make([]time.Time, benchBatchSize)
+	values := make([]float64, benchBatchSize)
+	ts := time.Now()
+	for n := range names {
+		names[n], times[n], values[n] = "tag", ts, float64(n)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := appender.AppendColumns(names, times, values); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestAppenderGroup(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	group := conn.AppenderGroup(context.TODO(),
+		machrpc.GroupFlushInterval(10*time.Millisecond),
+		machrpc.GroupIdleTimeout(50*time.Millisecond),
+	)
+	ts := time.Now()
+	for i := 0; i < 10; i++ {
+		require.Nil(t, group.Append("tagdata", "tag", ts, float64(i)))
+		require.Nil(t, group.Append("example", i))
+	}
+	require.ElementsMatch(t, []string{"TAGDATA", "EXAMPLE"}, group.Tables())
+
+	// flushed by the group
+	require.Eventually(t, func() bool {
+		return len(mockServer.AppendedRecords("tagdata")) == 10
+	}, time.Second, 10*time.Millisecond)
+
+	// closed by the idle timeout
+	require.Eventually(t, func() bool {
+		return len(group.Tables()) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// opened again
+	require.Nil(t, group.Append("tagdata", "tag", ts, 10.0))
+	require.Equal(t, []string{"TAGDATA"}, group.Tables())
+	require.Nil(t, group.Flush(context.TODO()))
+
+	counts, err := group.Close()
+	require.Nil(t, err)
+	require.Equal(t, map[string]machrpc.AppendCounts{
+		"TAGDATA": {Success: 11},
+		"EXAMPLE": {Success: 10},
+	}, counts)
+
+	require.ErrorIs(t, group.Append("tagdata", "tag", ts, 1.0), machrpc.ErrAppenderGroupClosed)
+
+	// the concurrent Appends open the table once
+	group = conn.AppenderGroup(context.TODO(), machrpc.GroupFlushInterval(0))
+	wg := sync.WaitGroup{}
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- group.Append("tagdata", "tag", ts, 1.0)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.Nil(t, err)
+	}
+	require.Equal(t, []string{"TAGDATA"}, group.Tables())
+	counts, err = group.Close()
+	require.Nil(t, err)
+	require.Equal(t, map[string]machrpc.AppendCounts{"TAGDATA": {Success: 8}}, counts)
+
+	// the callbacks of the appender closed by the idle timeout can call the group
+	var hookGroup atomic.Pointer[machrpc.AppenderGroup]
+	tables := make(chan []string, 10)
+	cli, err := machrpc.NewClient(&machrpc.Config{
+		ServerAddr: MockServerAddr,
+		Hooks: []machrpc.Hooks{&flushHooks{onFlush: func() {
+			tables <- hookGroup.Load().Tables()
+		}}},
+	})
+	require.Nil(t, err)
+	defer cli.Close()
+	hookConn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+	require.Nil(t, err)
+	defer hookConn.Close()
+	hookGroup.Store(hookConn.AppenderGroup(context.TODO(),
+		machrpc.GroupFlushInterval(0),
+		machrpc.GroupIdleTimeout(50*time.Millisecond),
+	))
+	require.Nil(t, hookGroup.Load().Append("tagdata", "tag", ts, 1.0))
+	select {
+	case names := <-tables:
+		require.Empty(t, names)
+	case <-time.After(5 * time.Second):
+		t.Fatal("idle appender was not closed")
+	}
+	counts, err = hookGroup.Load().Close()
+	require.Nil(t, err)
+	require.Equal(t, int64(1), counts["TAGDATA"].Success)
+}
+
+type flushHooks struct {
+	machrpc.NoopHooks
+	onFlush func()
+}
+
+func (h *flushHooks) OnAppendFlush(ctx context.Context, evt machrpc.TraceEvent) { h.onFlush() }
+
+func TestParallelAppender(t *testing.T) {
+	cli := newClient(t)
+	app, err := cli.ParallelAppender(context.TODO(), "tagdata",
+		machrpc.ParallelConnectOptions(machrpc.WithPassword("sys", "manager")),
+		machrpc.ParallelShards(4),
+		machrpc.ParallelAppenderOptions(machrpc.AppenderBufferThreshold(5)),
+	)
+	require.Nil(t, err)
+	require.Equal(t, "TAGDATA", app.TableName())
+
+	ts := time.Now()
+	for i := 0; i < 10; i++ {
+		for tag := 0; tag < 8; tag++ {
+			require.Nil(t, app.Append(fmt.Sprintf("tag-%d", tag), ts, float64(i)))
+		}
+	}
+	require.Nil(t, app.Flush(context.TODO()))
+
+	stats := app.Stats()
+	require.Equal(t, 4, stats.Shards)
+	require.Equal(t, int64(80), stats.Appended)
+	require.Equal(t, int64(80), stats.Sent)
+	for _, st := range stats.PerShard {
+		// records of a tag are in the same shard
+		require.Equal(t, int64(0), st.Appended%10)
+	}
+
+	succ, fail, err := app.Close()
+	require.Nil(t, err)
+	require.Equal(t, int64(80), succ)
+	require.Equal(t, int64(0), fail)
+
+	// each shard has its own spool directory, Close can be called concurrently
+	dir := t.TempDir()
+	app, err = cli.ParallelAppender(context.TODO(), "tagdata",
+		machrpc.ParallelConnectOptions(machrpc.WithPassword("sys", "manager")),
+		machrpc.ParallelShards(2),
+		machrpc.ParallelAppenderOptions(machrpc.AppenderSpool(machrpc.SpoolConfig{Dir: dir})),
+	)
+	require.Nil(t, err)
+	require.DirExists(t, filepath.Join(dir, "TAGDATA", "shard-0"))
+	require.DirExists(t, filepath.Join(dir, "TAGDATA", "shard-1"))
+	require.Nil(t, app.Append("tag", ts, 1.0))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			succ, _, err := app.Close()
+			require.Nil(t, err)
+			require.Equal(t, int64(1), succ)
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkParallelAppender(b *testing.B) {
+	for _, shards := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("shards-%d", shards), func(b *testing.B) {
+			cli := newClient(b)
+			app, err := cli.ParallelAppender(context.TODO(), "example",
+				machrpc.ParallelConnectOptions(machrpc.WithPassword("sys", "manager")),
+				machrpc.ParallelShards(shards),
+				machrpc.ParallelAppenderOptions(machrpc.AppenderBufferThreshold(benchBatchSize)),
+			)
+			require.Nil(b, err)
+			defer app.Close()
+
+			var seq atomic.Int32
+			ts := time.Now()
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				tag := fmt.Sprintf("tag-%d", seq.Add(1))
+				for n := 0; pb.Next(); n++ {
+					if err := app.Append(tag, ts, float64(n)); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestAppendTimeformat(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	ts := time.Unix(1700000000, 123000000)
+
+	appender, err := conn.Appender(context.TODO(), "tagdata", machrpc.AppenderTimeformat("ms"))
+	require.Nil(t, err)
+	require.Nil(t, appender.Append("tag1", ts.UnixMilli(), 1.0))
+	require.Nil(t, appender.Append("tag2", fmt.Sprintf("%d", ts.UnixMilli()), 2.0))
+	require.Nil(t, appender.Append("tag3", ts, 3.0))
+	require.Nil(t, appender.Append("tag4", nil, 4.0))
+	err = appender.Append("tag5", "2023-11-14", 5.0)
+	require.NotNil(t, err)
+	require.Equal(t, `convert column "TIME": invalid epoch time "2023-11-14"`, err.Error())
+	_, err = appender.AppendColumns([]string{"tag6"}, []time.Time{ts}, []any{1.0})
+	require.Nil(t, err)
+	_, _, err = appender.Close()
+	require.Nil(t, err)
+
+	recs := mockServer.AppendedRecords("tagdata")
+	require.Equal(t, 5, len(recs))
+	require.Equal(t, ts, recs[0][1])
+	require.Equal(t, ts, recs[1][1])
+	require.Equal(t, ts, recs[2][1])
+	require.Nil(t, recs[3][1])
+
+	seoul := time.FixedZone("KST", 9*60*60)
+	appender, err = conn.Appender(context.TODO(), "tagdata",
+		machrpc.AppenderTimeformat("2006-01-02 15:04:05.000"),
+		machrpc.AppenderTimeLocation(seoul))
+	require.Nil(t, err)
+	require.Nil(t, appender.Append("tag1", ts.In(seoul).Format("2006-01-02 15:04:05.000"), 1.0))
+	err = appender.Append("tag2", ts.UnixMilli(), 2.0)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), `for time layout "2006-01-02 15:04:05.000"`)
+	err = appender.Append("tag3", "2023-11-14T22:13:20Z", 3.0)
+	require.NotNil(t, err)
+	_, _, err = appender.Close()
+	require.Nil(t, err)
+
+	recs = mockServer.AppendedRecords("tagdata")
+	require.Equal(t, 1, len(recs))
+	require.True(t, ts.Equal(recs[0][1].(time.Time)))
+
+	// the server that does not report the columns converts the time
+	appender, err = conn.Appender(context.TODO(), "example", machrpc.AppenderTimeformat("ms"))
+	require.Nil(t, err)
+	require.Nil(t, appender.Append(ts.UnixMilli()))
+	succ, _, err := appender.Close()
+	require.Nil(t, err)
+	require.Equal(t, int64(1), succ)
+}
+
+func TestAppenderStats(t *testing.T) {
+	conn := newConn(t)
+	defer conn.Close()
+
+	appender, err := conn.Appender(context.TODO(), "tagdata",
+		machrpc.AppenderBufferThreshold(10),
+		machrpc.AppenderFlushInterval(0))
+	require.Nil(t, err)
+
+	ts := time.Now()
+	for i := 0; i < 25; i++ {
+		require.Nil(t, appender.Append("tag", ts, float64(i)))
+	}
+	stats := appender.Stats()
+	require.Equal(t, int64(25), stats.Appended)
+	require.Equal(t, int64(20), stats.Sent)
+	require.Equal(t, int64(2), stats.Batches)
+	require.Equal(t, int64(5), stats.Buffered)
+	require.Greater(t, stats.BytesSent, int64(0))
+	require.Greater(t, stats.LastFlushLatency, time.Duration(0))
+	require.Equal(t, int64(0), stats.SendErrors)
+
+	_, _, err = appender.Close()
+	require.Nil(t, err)
+	stats = appender.Stats()
+	require.Equal(t, int64(25), stats.Sent)
+	require.Equal(t, int64(3), stats.Batches)
+	require.Equal(t, int64(0), stats.Buffered)
+	require.Equal(t, int64(25), stats.ServerSuccess)
+	require.Equal(t, int64(0), stats.ServerFail)
+}
+
+func TestError(t *testing.T) {
+	cli := newClient(t)
+	_, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "wrong"))
+	require.ErrorIs(t, err, machrpc.ErrAuthFailed)
+	require.Equal(t, "Conn: invalid username or password", err.Error())
+	var rpcErr *machrpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, "Conn", rpcErr.RPC)
+	require.Equal(t, machrpc.ErrorAuth, rpcErr.Kind)
+	require.Equal(t, "1ms.", rpcErr.Elapse)
+
+	conn := newConn(t)
+	defer conn.Close()
+
+	_, err = conn.Query(context.TODO(), "select * from no_such_table")
+	require.ErrorIs(t, err, machrpc.ErrTableNotFound)
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, "Query", rpcErr.RPC)
+	require.Equal(t, "table not found", rpcErr.Kind.String())
+	require.Equal(t, "Query: Table 'NO_SUCH_TABLE' does not exist.", err.Error())
+
+	_, err = conn.Query(context.TODO(), "selec * from example")
+	require.ErrorIs(t, err, machrpc.ErrSyntax)
+	require.NotErrorIs(t, err, machrpc.ErrTableNotFound)
+
+	// the phrases of the reasons are anchored
+	reasons := map[string]machrpc.ErrorKind{
+		"MACH-ERR 2024 : Table 'T' does not exist.":    machrpc.ErrorTableNotFound,
+		"Column 'X' not found in table 'T'.":           machrpc.ErrorUnknown,
+		"too many sessions":                            machrpc.ErrorUnknown,
+		"invalid author of the statement":              machrpc.ErrorUnknown,
+		"invalid user":                                 machrpc.ErrorAuth,
+		"Syntax error: near token (x).":                machrpc.ErrorSyntax,
+		"column timeout_ms has invalid value":          machrpc.ErrorUnknown,
+		"query timeout":                                machrpc.ErrorTimeout,
+		"MACH-ERR 1234 : invalid username or password": machrpc.ErrorAuth,
+	}
+	for reason, kind := range reasons {
+		_, err = conn.Query(context.TODO(), "select * from error_reason where reason = ?", reason)
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, kind, rpcErr.Kind, reason)
+	}
+
+	// transport error
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+	_, err = conn.Query(ctx, "select * from example where name = ?", "query1")
+	require.ErrorIs(t, err, machrpc.ErrTimeout)
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(rpcErr.Err))
+}
+
+func TestClientClose(t *testing.T) {
+	cli := newClient(t)
+	conn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+	require.Nil(t, err)
+	closed, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+	require.Nil(t, err)
+	require.Nil(t, closed.Close())
+
+	rows, err := conn.Query(context.TODO(), "select * from example where name = ?", "query2")
+	require.Nil(t, err)
+	require.True(t, rows.Next())
+	appender, err := conn.Appender(context.TODO(), "tagdata", machrpc.AppenderFlushInterval(0))
+	require.Nil(t, err)
+	require.Nil(t, appender.Append("tag1", time.Now(), 1.0))
+	require.Equal(t, 3, len(cli.OpenResources()))
+
+	leaks, err := cli.CloseContext(context.TODO())
+	require.Nil(t, err)
+	require.Equal(t, 3, len(leaks))
+	require.Equal(t, "Appender", leaks[0].Kind)
+	require.Equal(t, "TAGDATA", leaks[0].Name)
+	require.Equal(t, "Rows", leaks[1].Kind)
+	require.Equal(t, "query2#1", leaks[1].Name)
+	require.Equal(t, "Conn", leaks[2].Kind)
+	require.Empty(t, cli.OpenResources())
+
+	// the appender is flushed, the rows are released
+	require.Equal(t, 1, len(mockServer.AppendedRecords("tagdata")))
+	require.False(t, mockServer.RowsOpened("query2#1"))
+
+	// closing again is no-op, and the closed resources are safe to close
+	leaks, err = cli.CloseContext(context.TODO())
+	require.Nil(t, err)
+	require.Empty(t, leaks)
+	require.Nil(t, rows.Close())
+	_, _, err = appender.Close()
+	require.Nil(t, err)
+	require.Nil(t, conn.Close())
+
+	_, err = cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+	require.ErrorIs(t, err, machrpc.ErrClientClosed)
+
+	// the conns connected while the client is closing
+	cli = newClient(t)
+	errs := make(chan error, 8)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				c, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+				if err != nil {
+					errs <- err
+					return
+				}
+				defer c.Close()
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	_, err = cli.CloseContext(context.TODO())
+	require.Nil(t, err)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.ErrorIs(t, err, machrpc.ErrClientClosed)
+	}
+}
+
+// compressionStats records the compression of the RPCs that the server received.
+type compressionStats struct {
+	compressions *sync.Map
+}
+
+func (cs compressionStats) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (cs compressionStats) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (cs compressionStats) HandleConn(context.Context, stats.ConnStats) {}
+
+func (cs compressionStats) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if in, ok := s.(*stats.InHeader); ok {
+		cs.compressions.Store(in.FullMethod, in.Compression)
+	}
+}
+
+func TestDialOptions(t *testing.T) {
+	var compressions sync.Map // full method -> compression
+	var mdLock sync.Mutex
+	received := map[string]metadata.MD{}
+	capture := func(ctx context.Context, method string) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		mdLock.Lock()
+		received[method] = md
+		mdLock.Unlock()
+	}
+	lastMD := func(method string) metadata.MD {
+		mdLock.Lock()
+		defer mdLock.Unlock()
+		return received[method]
+	}
+	addr := startServer(t,
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			capture(ctx, info.FullMethod)
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			capture(ss.Context(), info.FullMethod)
+			return handler(srv, ss)
+		}),
+		grpc.StatsHandler(compressionStats{&compressions}),
+	)
+
+	var unaryCalls, streamCalls atomic.Int32
+	cli, err := machrpc.NewClient(&machrpc.Config{
+		ServerAddr:        addr,
+		Keepalive:         &keepalive.ClientParameters{Time: 30 * time.Second, Timeout: 10 * time.Second},
+		AppendCompression: "gzip",
+		UserAgent:         "my-app/1.0",
+		Metadata:          map[string]string{"client": "my-app", "tenant": "t1"},
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				unaryCalls.Add(1)
+				// the interceptors see the metadata of the config
+				md, _ := metadata.FromOutgoingContext(ctx)
+				if len(md.Get("tenant")) == 0 {
+					return status.Error(codes.InvalidArgument, "no tenant")
+				}
+				return invoker(ctx, method, req, reply, cc, opts...)
+			},
+		},
+		StreamInterceptors: []grpc.StreamClientInterceptor{
+			func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				streamCalls.Add(1)
+				return streamer(ctx, desc, cc, method, opts...)
+			},
+		},
+	})
+	require.Nil(t, err)
+	defer cli.Close()
+
+	conn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+	require.Nil(t, err)
+	defer conn.Close()
+	_, err = conn.Ping()
+	require.Nil(t, err)
+	require.Greater(t, unaryCalls.Load(), int32(1))
+
+	md := lastMD("/machrpc.Machbase/Ping")
+	require.Equal(t, []string{"my-app"}, md.Get("client"))
+	require.Equal(t, []string{"t1"}, md.Get("tenant"))
+	require.Contains(t, md.Get("user-agent")[0], "my-app/1.0")
+
+	// the metadata of the caller is not overwritten
+	ctx := metadata.AppendToOutgoingContext(context.TODO(), "tenant", "t2")
+	rows, err := conn.Query(ctx, "select * from example where name = ?", "query1")
+	require.Nil(t, err)
+	require.Nil(t, rows.Close())
+	require.Equal(t, []string{"t2"}, lastMD("/machrpc.Machbase/Query").Get("tenant"))
+
+	appender, err := conn.Appender(context.TODO(), "tagdata")
+	require.Nil(t, err)
+	require.Nil(t, appender.Append("tag1", time.Now(), 1.0))
+	_, _, err = appender.Close()
+	require.Nil(t, err)
+	require.Greater(t, streamCalls.Load(), int32(0))
+	compression, _ := compressions.Load("/machrpc.Machbase/Append")
+	require.Equal(t, "gzip", compression)
+	compression, _ = compressions.Load("/machrpc.Machbase/Query")
+	require.Equal(t, "", compression)
+	require.Equal(t, 1, len(mockServer.AppendedRecords("tagdata")))
+}
+
+func TestMaxRecvMsgSize(t *testing.T) {
+	cli, err := machrpc.NewClient(&machrpc.Config{ServerAddr: MockServerAddr, MaxRecvMsgSize: 1024})
+	require.Nil(t, err)
+	defer cli.Close()
+	conn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+	require.Nil(t, err)
+	defer conn.Close()
+
+	// a batch of 1000 rows exceeds the limit
+	rows, err := conn.Query(context.TODO(), "select * from example where name = ?", "query2")
+	require.Nil(t, err)
+	defer rows.Close()
+	require.False(t, rows.Next())
+	require.Equal(t, codes.ResourceExhausted, status.Code(errors.Unwrap(rows.Err())))
+}
+
+// recordHooks records the events of the hooks.
+type recordHooks struct {
+	mu     sync.Mutex
+	events []string
+	last   map[string]machrpc.TraceEvent
+}
+
+func (h *recordHooks) record(callback string, evt machrpc.TraceEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, callback+" "+evt.Op)
+	if h.last == nil {
+		h.last = map[string]machrpc.TraceEvent{}
+	}
+	h.last[callback] = evt
+}
+
+func (h *recordHooks) OnQueryStart(ctx context.Context, evt machrpc.TraceEvent) {
+	h.record("OnQueryStart", evt)
+}
+
+func (h *recordHooks) OnQueryEnd(ctx context.Context, evt machrpc.TraceEvent) {
+	h.record("OnQueryEnd", evt)
+}
+
+func (h *recordHooks) OnExec(ctx context.Context, evt machrpc.TraceEvent) { h.record("OnExec", evt) }
+
+func (h *recordHooks) OnAppendFlush(ctx context.Context, evt machrpc.TraceEvent) {
+	h.record("OnAppendFlush", evt)
+}
+
+func (h *recordHooks) OnError(ctx context.Context, evt machrpc.TraceEvent) { h.record("OnError", evt) }
+
+// reset returns the recorded events and clears them.
+func (h *recordHooks) reset() ([]string, map[string]machrpc.TraceEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	events, last := h.events, h.last
+	h.events, h.last = nil, nil
+	return events, last
+}
+
+// syncBuffer is a bytes.Buffer that is safe to write from multiple goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestHooks(t *testing.T) {
+	hooks := &recordHooks{}
+	logs, slowLogs, noSlowLogs := &syncBuffer{}, &syncBuffer{}, &syncBuffer{}
+	newLogger := func(w *syncBuffer) *slog.Logger {
+		return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+	cli, err := machrpc.NewClient(&machrpc.Config{
+		ServerAddr: MockServerAddr,
+		Logger:     newLogger(logs),
+		Hooks: []machrpc.Hooks{
+			hooks,
+			machrpc.NewSlowQueryLogger(newLogger(slowLogs), 0),
+			machrpc.NewSlowQueryLogger(newLogger(noSlowLogs), time.Hour),
+		},
+		RedactParams: machrpc.RedactAll,
+	})
+	require.Nil(t, err)
+	defer cli.Close()
+	conn, err := cli.Connect(context.TODO(), machrpc.WithPassword("sys", "manager"))
+	require.Nil(t, err)
+	defer conn.Close()
+
+	// Exec
+	require.Nil(t, conn.Exec(context.TODO(), "insert into example (name, time, value) values(?, ?, ?)", 1, 2, 3).Err())
+	events, last := hooks.reset()
+	require.Equal(t, []string{"OnQueryStart Exec", "OnExec Exec"}, events)
+	evt := last["OnExec"]
+	require.Equal(t, "insert into example (name, time, value) values(?, ?, ?)", evt.SQL)
+	require.Equal(t, []any{"?", "?", "?"}, evt.Params)
+	require.Equal(t, int64(1), evt.RowsAffected)
+	require.Equal(t, "1ms.", evt.ServerElapse)
+	require.Greater(t, evt.Elapsed, time.Duration(0))
+	require.Nil(t, evt.Err)
+
+	// QueryRow
+	require.Nil(t, conn.QueryRow(context.TODO(), "select count(*) from example where name = ?", "query1").Err())
+	events, last = hooks.reset()
+	require.Equal(t, []string{"OnQueryStart QueryRow", "OnQueryEnd QueryRow"}, events)
+	require.Equal(t, int64(1), last["OnQueryEnd"].RowsAffected)
+
+	// Query
+	rows, err := conn.Query(context.TODO(), "select * from example where name = ?", "query1")
+	require.Nil(t, err)
+	require.Nil(t, rows.Close())
+	events, last = hooks.reset()
+	require.Equal(t, []string{"OnQueryStart Query", "OnQueryEnd Query"}, events)
+	require.Equal(t, "1ms.", last["OnQueryEnd"].ServerElapse)
+
+	// failure
+	_, err = conn.Query(context.TODO(), "select * from no_such_table")
+	require.ErrorIs(t, err, machrpc.ErrTableNotFound)
+	events, last = hooks.reset()
+	require.Equal(t, []string{"OnQueryStart Query", "OnQueryEnd Query", "OnError Query"}, events)
+	require.ErrorIs(t, last["OnError"].Err, machrpc.ErrTableNotFound)
+	require.Equal(t, "1ms.", last["OnError"].ServerElapse)
+	require.Nil(t, last["OnError"].Params)
+
+	// Append
+	appender, err := conn.Appender(context.TODO(), "tagdata", machrpc.AppenderFlushInterval(0))
+	require.Nil(t, err)
+	require.Nil(t, appender.Append("tag1", time.Now(), 1.0))
+	require.Nil(t, appender.Append("tag2", time.Now(), 2.0))
+	_, _, err = appender.Close()
+	require.Nil(t, err)
+	events, last = hooks.reset()
+	require.Equal(t, []string{"OnAppendFlush Append"}, events)
+	require.Equal(t, "TAGDATA", last["OnAppendFlush"].Table)
+	require.Equal(t, 2, last["OnAppendFlush"].Records)
+
+	// Logger
+	require.Contains(t, logs.String(), `level=DEBUG msg="machrpc exec" op=Exec sql="insert into example (name, time, value) values(?, ?, ?)" params="[? ? ?]" rows_affected=1`)
+	require.Contains(t, logs.String(), `level=ERROR msg="machrpc Query failed" op=Query sql="select * from no_such_table"`)
+	require.Contains(t, logs.String(), `level=DEBUG msg="machrpc append flush" op=Append table=TAGDATA records=2`)
+	// slow query logger
+	require.Contains(t, slowLogs.String(), `level=WARN msg="machrpc slow QueryRow"`)
+	require.Contains(t, slowLogs.String(), `level=WARN msg="machrpc slow Append"`)
+	require.NotContains(t, slowLogs.String(), "level=ERROR")
+	require.Empty(t, noSlowLogs.String())
+}